@@ -73,6 +73,66 @@ func InitializeDatabase() (*gorm.DB, error) {
 	db.AutoMigrate(&models.KPAIndicator{})
 	db.AutoMigrate(&models.ResetPasswordOTP{})
 	db.AutoMigrate(&models.AdminResetPasswordOTP{})
+	// Hapus roster ganda (employee_id, roster_date) sebelum unique index dibuat, entri terbaru dipertahankan
+	if db.Migrator().HasTable(&models.ShiftRoster{}) {
+		db.Exec("DELETE FROM shift_rosters a USING shift_rosters b WHERE a.employee_id = b.employee_id AND a.roster_date = b.roster_date AND a.id < b.id")
+	}
+	db.AutoMigrate(&models.ShiftRoster{})
+	db.AutoMigrate(&models.ShiftSwapRequest{})
+	db.AutoMigrate(&models.Holiday{})
+	db.AutoMigrate(&models.AttendanceCorrection{})
+	db.AutoMigrate(&models.AttendanceAuditLog{})
+	db.AutoMigrate(&models.AttendanceFlag{})
+	db.AutoMigrate(&models.Kiosk{})
+	db.AutoMigrate(&models.KioskPINAttempt{})
+	db.AutoMigrate(&models.EmploymentHistory{})
+	db.AutoMigrate(&models.EmploymentContract{})
+	db.AutoMigrate(&models.DocumentType{})
+	db.AutoMigrate(&models.EmployeeDocument{})
+	db.AutoMigrate(&models.HelpdeskComment{})
+	db.AutoMigrate(&models.HelpdeskAttachment{})
+	db.AutoMigrate(&models.HelpdeskStatusHistory{})
+	db.AutoMigrate(&models.HelpdeskSLAPolicy{})
+	db.AutoMigrate(&models.HelpdeskAgent{})
+	db.AutoMigrate(&models.KnowledgeBaseCategory{})
+	db.AutoMigrate(&models.KnowledgeBaseArticle{})
+	db.AutoMigrate(&models.KnowledgeBaseSuggestion{})
+	db.AutoMigrate(&models.AnnouncementRead{})
+	// Hapus versi ganda (policy_id, version_number) sebelum unique index dibuat, versi pertama dipertahankan
+	if db.Migrator().HasTable(&models.PolicyVersion{}) {
+		db.Exec("UPDATE policy_acknowledgements a SET policy_version_id = k.id FROM policy_versions d, (SELECT policy_id, version_number, MIN(id) AS id FROM policy_versions GROUP BY policy_id, version_number) k WHERE a.policy_version_id = d.id AND d.policy_id = k.policy_id AND d.version_number = k.version_number AND d.id <> k.id AND NOT EXISTS (SELECT 1 FROM policy_acknowledgements x WHERE x.policy_version_id = k.id AND x.employee_id = a.employee_id)")
+		db.Exec("DELETE FROM policy_acknowledgements a USING policy_versions d, policy_versions k WHERE a.policy_version_id = d.id AND d.policy_id = k.policy_id AND d.version_number = k.version_number AND k.id < d.id")
+		db.Exec("DELETE FROM policy_versions a USING policy_versions b WHERE a.policy_id = b.policy_id AND a.version_number = b.version_number AND a.id > b.id")
+	}
+	db.AutoMigrate(&models.PolicyVersion{})
+	// Kebijakan lama yang belum memiliki versi dibuatkan versi 1 dari isi awal kebijakan
+	db.Exec("INSERT INTO policy_versions (policy_id, version_number, content, change_summary, effective_date, published_by_admin_id, published_by_admin_username, created_at) SELECT p.id, 1, p.description, 'Initial version', TO_CHAR(COALESCE(p.created_at, NOW()), 'YYYY-MM-DD'), p.created_by_admin_id, p.created_by_admin_username, NOW() FROM policies p WHERE NOT EXISTS (SELECT 1 FROM policy_versions v WHERE v.policy_id = p.id)")
+	db.Exec("UPDATE policies p SET latest_version = (SELECT MAX(v.version_number) FROM policy_versions v WHERE v.policy_id = p.id) WHERE p.latest_version = 0")
+	db.AutoMigrate(&models.PolicyAcknowledgement{})
+	db.AutoMigrate(&models.RecruitmentStage{})
+	db.AutoMigrate(&models.Candidate{})
+	db.AutoMigrate(&models.CandidateStageHistory{})
+	db.AutoMigrate(&models.Interview{})
+	db.AutoMigrate(&models.InterviewInterviewer{})
+	db.AutoMigrate(&models.InterviewScorecard{})
+	db.AutoMigrate(&models.OnboardingTemplate{})
+	db.AutoMigrate(&models.OnboardingTemplateTask{})
+	db.AutoMigrate(&models.OnboardingChecklist{})
+	db.AutoMigrate(&models.OnboardingTask{})
+	db.AutoMigrate(&models.LetterTemplate{})
+	db.AutoMigrate(&models.OfferLetter{})
+	db.AutoMigrate(&models.OfferLetterAllowance{})
+	db.AutoMigrate(&models.OffboardingChecklist{})
+	db.AutoMigrate(&models.OffboardingTask{})
+	db.AutoMigrate(&models.FinalSettlement{})
+
+	// Kolom full-text search artikel knowledge base, judul diberi bobot lebih tinggi dari tag dan isi
+	db.Exec(`ALTER TABLE knowledge_base_articles ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(tags, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(content, '')), 'C')
+	) STORED`)
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_knowledge_base_articles_search_vector ON knowledge_base_articles USING GIN (search_vector)")
 
 	return db, nil
 }
@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmployeeAnnouncementResponse adalah pengumuman beserta status baca milik karyawan yang login
+type EmployeeAnnouncementResponse struct {
+	AnnouncementResponse
+	IsRead         bool       `json:"is_read"`
+	ReadAt         *time.Time `json:"read_at"`
+	IsAcknowledged bool       `json:"is_acknowledged"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+}
+
+// activeEmployeeAnnouncements membatasi pengumuman yang sedang aktif untuk departemen karyawan atau seluruh perusahaan
+func activeEmployeeAnnouncements(db *gorm.DB, employee models.Employee) *gorm.DB {
+	// Periode pengumuman mengikuti tanggal Jakarta, bukan zona waktu server
+	currentTime := time.Now()
+	if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+		currentTime = currentTime.In(loc)
+	}
+	today := currentTime.Format("2006-01-02")
+	return db.Model(&models.Announcement{}).
+		Where("start_date <= ? AND end_date >= ?", today, today).
+		Where("department_id = ? OR department_id = ?", employee.DepartmentID, 0)
+}
+
+func employeeAnnouncementResponse(announcement models.Announcement, read models.AnnouncementRead) EmployeeAnnouncementResponse {
+	return EmployeeAnnouncementResponse{
+		AnnouncementResponse: AnnouncementResponse{
+			ID:             announcement.ID,
+			Title:          announcement.Title,
+			DepartmentID:   announcement.DepartmentID,
+			DepartmentName: announcement.DepartmentName,
+			Summary:        announcement.Summary,
+			Description:    announcement.Description,
+			StartDate:      announcement.StartDate,
+			EndDate:        announcement.EndDate,
+			CreatedAt:      announcement.CreatedAt,
+		},
+		IsRead:         read.ReadAt != nil,
+		ReadAt:         read.ReadAt,
+		IsAcknowledged: read.AcknowledgedAt != nil,
+		AcknowledgedAt: read.AcknowledgedAt,
+	}
+}
+
+// markAnnouncementRead menyimpan status baca, acknowledge sekaligus menandai pengumuman sebagai dibaca
+func markAnnouncementRead(db *gorm.DB, announcementID, employeeID uint, acknowledge bool) (models.AnnouncementRead, error) {
+	var read models.AnnouncementRead
+	db.Where("announcement_id = ? AND employee_id = ?", announcementID, employeeID).First(&read)
+
+	currentTime := time.Now()
+	read.AnnouncementID = announcementID
+	read.EmployeeID = employeeID
+	if read.ReadAt == nil {
+		read.ReadAt = &currentTime
+	}
+	if acknowledge && read.AcknowledgedAt == nil {
+		read.AcknowledgedAt = &currentTime
+	}
+
+	err := db.Save(&read).Error
+	return read, err
+}
+
+// GetAnnouncementsByEmployee menampilkan pengumuman aktif, filter status=read|unread
+func GetAnnouncementsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		readSubQuery := db.Model(&models.AnnouncementRead{}).Select("announcement_id").Where("employee_id = ? AND read_at IS NOT NULL", employee.ID)
+
+		query := activeEmployeeAnnouncements(db, employee)
+		switch c.QueryParam("status") {
+		case "read":
+			query = query.Where("id IN (?)", readSubQuery)
+		case "unread":
+			query = query.Where("id NOT IN (?)", readSubQuery)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var unreadCount int64
+		activeEmployeeAnnouncements(db, employee).Where("id NOT IN (?)", readSubQuery).Count(&unreadCount)
+
+		var announcements []models.Announcement
+		if err := query.Order("start_date DESC").Order("id DESC").Offset(offset).Limit(perPage).Find(&announcements).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching announcements"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var announcementIDs []uint
+		for _, announcement := range announcements {
+			announcementIDs = append(announcementIDs, announcement.ID)
+		}
+
+		var reads []models.AnnouncementRead
+		if len(announcementIDs) > 0 {
+			db.Where("employee_id = ? AND announcement_id IN ?", employee.ID, announcementIDs).Find(&reads)
+		}
+		readMap := make(map[uint]models.AnnouncementRead)
+		for _, read := range reads {
+			readMap[read.AnnouncementID] = read
+		}
+
+		response := []EmployeeAnnouncementResponse{}
+		for _, announcement := range announcements {
+			response = append(response, employeeAnnouncementResponse(announcement, readMap[announcement.ID]))
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Announcements retrieved successfully",
+			"data":         response,
+			"unread_count": unreadCount,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+// GetAnnouncementByIDByEmployee menampilkan detail pengumuman dan otomatis menandainya sebagai sudah dibaca
+func GetAnnouncementByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var announcement models.Announcement
+		if err := activeEmployeeAnnouncements(db, employee).Where("id = ?", c.Param("id")).First(&announcement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Announcement not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		read, err := markAnnouncementRead(db, announcement.ID, employee.ID, false)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to mark announcement as read"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Announcement retrieved successfully",
+			"announcement": employeeAnnouncementResponse(announcement, read),
+		})
+	}
+}
+
+func AcknowledgeAnnouncementByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var announcement models.Announcement
+		if err := activeEmployeeAnnouncements(db, employee).Where("id = ?", c.Param("id")).First(&announcement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Announcement not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		read, err := markAnnouncementRead(db, announcement.ID, employee.ID, true)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to acknowledge announcement"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Announcement acknowledged successfully",
+			"announcement": employeeAnnouncementResponse(announcement, read),
+		})
+	}
+}
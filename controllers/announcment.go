@@ -12,6 +12,9 @@ import (
 	"time"
 )
 
+// companyWideAnnouncement adalah nama departemen untuk pengumuman dengan DepartmentID 0
+const companyWideAnnouncement = "All Departments"
+
 type AnnouncementResponse struct {
 	ID             uint       `gorm:"primaryKey" json:"id"`
 	Title          string     `json:"title"`
@@ -107,14 +110,19 @@ func CreateAnnouncementByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		announcement.StartDate = startDate.Format("2006-01-02")
 		announcement.EndDate = endDate.Format("2006-01-02")
 
-		var existingDepartment models.Department
-		result = db.First(&existingDepartment, announcement.DepartmentID)
-		if result.Error != nil {
-			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Department not found"}
-			return c.JSON(http.StatusNotFound, errorResponse)
-		}
+		// DepartmentID 0 berarti pengumuman untuk seluruh karyawan
+		if announcement.DepartmentID == 0 {
+			announcement.DepartmentName = companyWideAnnouncement
+		} else {
+			var existingDepartment models.Department
+			result = db.First(&existingDepartment, announcement.DepartmentID)
+			if result.Error != nil {
+				errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Department not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
 
-		announcement.DepartmentName = existingDepartment.DepartmentName
+			announcement.DepartmentName = existingDepartment.DepartmentName
+		}
 
 		currentTime := time.Now()
 		announcement.CreatedAt = &currentTime
@@ -409,6 +417,9 @@ func GetAnnouncementsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var departmentIDs []uint
 		departmentMap := make(map[uint]string)
 		for _, ann := range announcements {
+			if ann.DepartmentID == 0 {
+				continue
+			}
 			departmentIDs = append(departmentIDs, ann.DepartmentID)
 			departmentMap[ann.DepartmentID] = ""
 		}
@@ -1179,6 +1190,7 @@ func DeleteAnnouncementForAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
+		db.Where("announcement_id = ?", announcement.ID).Delete(&models.AnnouncementRead{})
 		db.Delete(&announcement)
 
 		successResponse := map[string]interface{}{
@@ -1189,3 +1201,125 @@ func DeleteAnnouncementForAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 		return c.JSON(http.StatusOK, successResponse)
 	}
 }
+
+// GetAnnouncementReadReportByAdmin menampilkan ringkasan baca pengumuman dan daftar karyawan yang belum membaca,
+// gunakan status=unacknowledged untuk melihat karyawan yang belum mengonfirmasi
+func GetAnnouncementReadReportByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var announcement models.Announcement
+		result = db.First(&announcement, c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Announcement not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		// Karyawan yang menjadi target pengumuman
+		targetEmployees := func() *gorm.DB {
+			query := db.Model(&models.Employee{}).Where("is_client = ? AND is_exit = ?", false, false)
+			if announcement.DepartmentID != 0 {
+				query = query.Where("department_id = ?", announcement.DepartmentID)
+			}
+			return query
+		}
+
+		var totalTargets, readCount, acknowledgedCount int64
+		targetEmployees().Count(&totalTargets)
+		targetEmployees().Where("id IN (?)", db.Model(&models.AnnouncementRead{}).Select("employee_id").Where("announcement_id = ? AND read_at IS NOT NULL", announcement.ID)).Count(&readCount)
+		targetEmployees().Where("id IN (?)", db.Model(&models.AnnouncementRead{}).Select("employee_id").Where("announcement_id = ? AND acknowledged_at IS NOT NULL", announcement.ID)).Count(&acknowledgedCount)
+
+		pendingColumn := "read_at"
+		if c.QueryParam("status") == "unacknowledged" {
+			pendingColumn = "acknowledged_at"
+		}
+		pendingQuery := targetEmployees().Where("id NOT IN (?)", db.Model(&models.AnnouncementRead{}).Select("employee_id").Where("announcement_id = ? AND "+pendingColumn+" IS NOT NULL", announcement.ID))
+
+		var totalPending int64
+		pendingQuery.Count(&totalPending)
+
+		var employees []models.Employee
+		pendingQuery.Order("first_name ASC").Offset(offset).Limit(perPage).Find(&employees)
+
+		pendingEmployees := []map[string]interface{}{}
+		for _, employee := range employees {
+			pendingEmployees = append(pendingEmployees, map[string]interface{}{
+				"employee_id": employee.ID,
+				"username":    employee.Username,
+				"full_name":   employee.FirstName + " " + employee.LastName,
+				"email":       employee.Email,
+				"department":  employee.Department,
+			})
+		}
+
+		readRate := 0.0
+		if totalTargets > 0 {
+			readRate = float64(readCount) / float64(totalTargets) * 100
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Announcement read report retrieved successfully",
+			"summary": map[string]interface{}{
+				"announcement_id": announcement.ID,
+				"title":           announcement.Title,
+				"department_name": announcement.DepartmentName,
+				"total_employees": totalTargets,
+				"read":            readCount,
+				"unread":          totalTargets - readCount,
+				"acknowledged":    acknowledgedCount,
+				"read_rate":       readRate,
+			},
+			"data": pendingEmployees,
+			"pagination": map[string]interface{}{
+				"total_count": totalPending,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"hrsale/models"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +23,78 @@ func calculateEarlyLeavingMinutes(earlyLeavingDuration string) int {
 	return earlyLeavingMinutes
 }
 
+// recalculateAttendance menghitung ulang Late, EarlyLeaving, TotalWork dan status absensi
+// berdasarkan shift efektif karyawan pada tanggal absensi
+func recalculateAttendance(db *gorm.DB, employee models.Employee, attendance *models.Attendance) error {
+	attendanceDate, err := time.Parse("2006-01-02", attendance.AttendanceDate)
+	if err != nil {
+		return err
+	}
+
+	shift, shiftInTime, shiftOutTime, err := getShiftWithTimesForDay(db, getEffectiveShiftID(db, employee, attendance.AttendanceDate), attendanceDate.Weekday().String())
+	if err != nil {
+		return err
+	}
+
+	inTime, err := time.Parse("15:04:05", attendance.InTime)
+	if err != nil {
+		return err
+	}
+	attendance.Late, attendance.LateMinutes = applyLateRules(shift, calculateLate(shiftInTime, attendance.InTime))
+
+	if attendance.OutTime == "" {
+		attendance.EarlyLeaving = ""
+		attendance.EarlyLeavingMinutes = 0
+		attendance.TotalWork = ""
+		attendance.Status = "Present"
+		return nil
+	}
+
+	outTime, err := time.Parse("15:04:05", attendance.OutTime)
+	if err != nil {
+		return err
+	}
+	attendance.EarlyLeaving, attendance.EarlyLeavingMinutes = applyEarlyLeavingRules(shift, calculateEarlyLeaving(shiftOutTime, attendance.OutTime))
+
+	workDuration := calculateNetWork(shift, inTime, outTime)
+	attendance.TotalWork = strconv.FormatFloat(workDuration.Hours(), 'f', 2, 64) + " hours"
+	attendance.Status = attendanceStatusForWork(shift, workDuration)
+	return nil
+}
+
+// newAttendanceAuditLog menyiapkan audit log dengan nilai absensi sebelum diubah
+func newAttendanceAuditLog(attendance models.Attendance, source string, sourceID uint) models.AttendanceAuditLog {
+	return models.AttendanceAuditLog{
+		AttendanceID:    attendance.ID,
+		EmployeeID:      attendance.EmployeeID,
+		AttendanceDate:  attendance.AttendanceDate,
+		Source:          source,
+		SourceID:        sourceID,
+		OldInTime:       attendance.InTime,
+		OldOutTime:      attendance.OutTime,
+		OldStatus:       attendance.Status,
+		OldLate:         attendance.Late,
+		OldEarlyLeaving: attendance.EarlyLeaving,
+		OldTotalWork:    attendance.TotalWork,
+	}
+}
+
+// completeAttendanceAuditLog melengkapi audit log dengan nilai absensi setelah diubah
+func completeAttendanceAuditLog(auditLog *models.AttendanceAuditLog, attendance models.Attendance, role string, changedByID uint, changedByName string) {
+	currentTime := time.Now()
+	auditLog.AttendanceID = attendance.ID
+	auditLog.NewInTime = attendance.InTime
+	auditLog.NewOutTime = attendance.OutTime
+	auditLog.NewStatus = attendance.Status
+	auditLog.NewLate = attendance.Late
+	auditLog.NewEarlyLeaving = attendance.EarlyLeaving
+	auditLog.NewTotalWork = attendance.TotalWork
+	auditLog.ChangedByRole = role
+	auditLog.ChangedByID = changedByID
+	auditLog.ChangedByName = changedByName
+	auditLog.CreatedAt = &currentTime
+}
+
 func AddManualAttendanceByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		tokenString := c.Request().Header.Get("Authorization")
@@ -84,15 +157,15 @@ func AddManualAttendanceByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid out_time format. Required format: HH:mm"})
 		}
 
-		shiftInTime, shiftOutTime, err := getShiftForDay(db, employee.ShiftID, attendanceDate.Weekday().String())
+		shift, shiftInTime, shiftOutTime, err := getShiftWithTimesForDay(db, getEffectiveShiftID(db, employee, attendance.AttendanceDate), attendanceDate.Weekday().String())
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"})
 		}
 
-		lateDuration := calculateLate(shiftInTime, inTime.Format("15:04:05"))
-		earlyLeavingDuration := calculateEarlyLeaving(shiftOutTime, outTime.Format("15:04:05"))
+		lateDuration, lateMinutes := applyLateRules(shift, calculateLate(shiftInTime, inTime.Format("15:04:05")))
+		earlyLeavingDuration, earlyLeavingMinutes := applyEarlyLeavingRules(shift, calculateEarlyLeaving(shiftOutTime, outTime.Format("15:04:05")))
 
-		workDuration := outTime.Sub(inTime)
+		workDuration := calculateNetWork(shift, inTime, outTime)
 		totalWorkHours := workDuration.Hours()
 		totalWork := strconv.FormatFloat(totalWorkHours, 'f', 2, 64) + " hours"
 
@@ -103,13 +176,10 @@ func AddManualAttendanceByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 			hourlyRate = employee.HourlyRate
 		}
 
-		lateMinutes := calculateLateMinutes(lateDuration)
-		earlyLeavingMinutes := calculateEarlyLeavingMinutes(earlyLeavingDuration)
-
 		lateDeduction := (float64(lateMinutes) / 60) * hourlyRate
 		earlyLeavingDeduction := (float64(earlyLeavingMinutes) / 60) * hourlyRate
 
-		attendance.Status = "Present"
+		attendance.Status = attendanceStatusForWork(shift, workDuration)
 		attendance.Late = lateDuration
 		attendance.LateMinutes = lateMinutes
 		attendance.EarlyLeaving = earlyLeavingDuration
@@ -688,25 +758,23 @@ func UpdateAttendanceByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid out_time format. Required format: HH:mm:ss"})
 			}
 
-			log.Printf("Fetching shift data for ShiftID: %d and Day: %s\n", employee.ShiftID, attendanceDate.Weekday().String())
+			shiftID := getEffectiveShiftID(db, employee, attendance.AttendanceDate)
+			log.Printf("Fetching shift data for ShiftID: %d and Day: %s\n", shiftID, attendanceDate.Weekday().String())
 
-			shiftInTime, shiftOutTime, err := getShiftForDay(db, employee.ShiftID, attendanceDate.Weekday().String())
+			shift, shiftInTime, shiftOutTime, err := getShiftWithTimesForDay(db, shiftID, attendanceDate.Weekday().String())
 			if err != nil {
 				log.Printf("Failed to fetch shift data: %v\n", err)
 				return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"})
 			}
 
-			lateDuration := calculateLate(shiftInTime, inTime.Format("15:04:05"))
-			earlyLeavingDuration := calculateEarlyLeaving(shiftOutTime, outTime.Format("15:04:05"))
+			lateDuration, lateMinutes := applyLateRules(shift, calculateLate(shiftInTime, inTime.Format("15:04:05")))
+			earlyLeavingDuration, earlyLeavingMinutes := applyEarlyLeavingRules(shift, calculateEarlyLeaving(shiftOutTime, outTime.Format("15:04:05")))
 
-			workDuration := outTime.Sub(inTime)
+			workDuration := calculateNetWork(shift, inTime, outTime)
 			totalWorkHours := workDuration.Hours()
 			totalWork := strconv.FormatFloat(totalWorkHours, 'f', 2, 64) + " hours"
 
-			lateMinutes := calculateLateMinutes(lateDuration)
-			earlyLeavingMinutes := calculateEarlyLeavingMinutes(earlyLeavingDuration)
-
-			attendance.Status = "Present"
+			attendance.Status = attendanceStatusForWork(shift, workDuration)
 			attendance.Late = lateDuration
 			attendance.LateMinutes = lateMinutes
 			attendance.EarlyLeaving = earlyLeavingDuration
@@ -963,6 +1031,11 @@ func CreateOvertimeRequestByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		if err := validateOvertimeAgainstAttendance(db, employee, overtime, 0); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
 		inTime, err := time.Parse("15:04", overtime.InTime)
 		if err != nil {
 			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid in_time format. Required format: HH:mm"}
@@ -1563,6 +1636,18 @@ func UpdateOvertimeRequestByIDByAdmin(db *gorm.DB, secretKey []byte) echo.Handle
 			overtime.Status = updatedOvertime.Status
 		}
 
+		if updatedOvertime.EmployeeID != 0 || updatedOvertime.Date != "" || updatedOvertime.InTime != "" || updatedOvertime.OutTime != "" {
+			var employee models.Employee
+			if err := db.First(&employee, overtime.EmployeeID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid employee ID. Employee not found."}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if err := validateOvertimeAgainstAttendance(db, employee, overtime, overtime.ID); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
 		// Recalculate work duration, total work hours, and total minutes if in_time or out_time has changed
 		inTime, err := time.Parse("15:04", overtime.InTime)
 		if err != nil {
@@ -1780,54 +1865,301 @@ func DeleteOvertimeRequestByIDByAdmin(db *gorm.DB, secretKey []byte) echo.Handle
 	}
 }
 
+// AbsenceRunSummary merangkum hasil satu kali proses penandaan absen
+type AbsenceRunSummary struct {
+	Date         string `json:"date"`
+	MarkedAbsent int    `json:"marked_absent"`
+	MarkedLeave  int    `json:"marked_leave"`
+	Corrected    int    `json:"corrected"`
+	SkippedRest  int    `json:"skipped_rest_day"`
+	SkippedHire  int    `json:"skipped_not_hired"`
+	IsHoliday    bool   `json:"is_holiday"`
+}
+
 func MarkAbsentEmployees(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		log.Printf("Failed to load timezone: %v\n", err)
+		return
+	}
+
+	today := time.Now().In(loc).Format("2006-01-02")
+	summary, err := MarkAbsentEmployeesForDate(db, today)
+	if err != nil {
+		log.Printf("Failed to mark absent employees on %s: %v\n", today, err)
+		return
+	}
+	log.Printf("Absence job finished for %s: %d absent, %d on leave, %d corrected\n", today, summary.MarkedAbsent, summary.MarkedLeave, summary.Corrected)
+}
+
+// MarkAbsentEmployeesForDate menandai karyawan yang tidak hadir pada tanggal tertentu.
+// Cuti yang disetujui dicatat sebagai "On Leave", sedangkan hari libur, hari istirahat shift
+// dan karyawan yang belum bergabung dilewati. Aman dijalankan ulang: hanya record "Absent"
+// dan "On Leave" yang dibuat proses ini yang diperbaiki, absensi lain tidak disentuh.
+func MarkAbsentEmployeesForDate(db *gorm.DB, date string) (AbsenceRunSummary, error) {
+	summary := AbsenceRunSummary{Date: date}
+
+	attendanceDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return summary, err
+	}
+
 	var employees []models.Employee
-	db.Where("is_client = ? AND is_exit = ?", false, false).Find(&employees)
+	if err := db.Where("is_client = ? AND is_exit = ?", false, false).Find(&employees).Error; err != nil {
+		return summary, err
+	}
 
-	today := time.Now().Format("2006-01-02")
+	summary.IsHoliday = isHoliday(db, date)
+	// Tanggal bergabung dibandingkan dalam kalender Asia/Jakarta
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.Local
+	}
 
 	for _, employee := range employees {
 		var existingAttendance models.Attendance
-		result := db.Where("employee_id = ? AND attendance_date = ?", employee.ID, today).First(&existingAttendance)
-		if result.Error != nil {
-			shiftInTime, shiftOutTime, err := getShiftForDay(db, employee.ShiftID, time.Now().Weekday().String())
+		hasAttendance := db.Where("employee_id = ? AND attendance_date = ?", employee.ID, date).First(&existingAttendance).Error == nil
+		if hasAttendance && existingAttendance.Status != "Absent" && existingAttendance.Status != "On Leave" {
+			continue
+		}
+
+		// Tentukan status yang seharusnya untuk tanggal tersebut
+		status := ""
+		switch {
+		case employee.CreatedAt != nil && employee.CreatedAt.In(loc).Format("2006-01-02") > date:
+			summary.SkippedHire++
+		case summary.IsHoliday:
+		case isOnApprovedLeave(db, employee.ID, date):
+			status = "On Leave"
+		default:
+			shiftInTime, shiftOutTime, err := getShiftForDay(db, getEffectiveShiftID(db, employee, date), attendanceDate.Weekday().String())
 			if err != nil {
 				log.Printf("Failed to fetch shift data for employee %s: %v\n", employee.Username, err)
 				continue
 			}
+			if shiftInTime == "" || shiftOutTime == "" {
+				summary.SkippedRest++
+			} else {
+				status = "Absent"
+			}
+		}
 
-			// Parse shift times
-			shiftIn, err := time.Parse("15:04:05", shiftInTime)
-			if err != nil {
-				log.Printf("Failed to parse shift in time for employee %s: %v\n", employee.Username, err)
-				continue
+		if hasAttendance {
+			if status == "" {
+				db.Delete(&existingAttendance)
+				summary.Corrected++
+			} else if existingAttendance.Status != status || existingAttendance.LateMinutes != 0 {
+				existingAttendance.Status = status
+				existingAttendance.LateMinutes = 0
+				db.Save(&existingAttendance)
+				summary.Corrected++
 			}
+			continue
+		}
+
+		if status == "" {
+			continue
+		}
+
+		currentTime := time.Now()
+		attendance := models.Attendance{
+			EmployeeID:       employee.ID,
+			Username:         employee.Username,
+			FullNameEmployee: employee.FirstName + " " + employee.LastName,
+			AttendanceDate:   date,
+			InTime:           "",
+			OutTime:          "",
+			TotalWork:        "",
+			Status:           status,
+			CreatedAt:        &currentTime,
+		}
+		db.Create(&attendance)
+
+		if status == "On Leave" {
+			summary.MarkedLeave++
+		} else {
+			summary.MarkedAbsent++
+		}
+		log.Printf("Marked employee %s as %s on %s\n", employee.Username, status, date)
+	}
+
+	return summary, nil
+}
+
+// isOnApprovedLeave mengecek apakah karyawan memiliki cuti yang disetujui pada tanggal tersebut
+func isOnApprovedLeave(db *gorm.DB, employeeID uint, date string) bool {
+	var count int64
+	db.Model(&models.LeaveRequest{}).
+		Where("employee_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeID, "Approved", date, date).
+		Count(&count)
+	return count > 0
+}
+
+// missingCheckoutPolicy membaca kebijakan dari env AUTO_CHECKOUT_POLICY:
+// "auto_close" menutup absensi pada jam akhir shift, "flag" (default) menandai "Missing Checkout"
+func missingCheckoutPolicy() string {
+	if strings.ToLower(os.Getenv("AUTO_CHECKOUT_POLICY")) == "auto_close" {
+		return "auto_close"
+	}
+	return "flag"
+}
+
+// missingCheckoutBuffer membaca env AUTO_CHECKOUT_BUFFER_MINUTES, default 120 menit setelah shift berakhir
+func missingCheckoutBuffer() time.Duration {
+	bufferMinutes, err := strconv.Atoi(os.Getenv("AUTO_CHECKOUT_BUFFER_MINUTES"))
+	if err != nil || bufferMinutes < 0 {
+		bufferMinutes = 120
+	}
+	return time.Duration(bufferMinutes) * time.Minute
+}
+
+// HandleMissingCheckouts memproses absensi yang belum checkout setelah jam akhir shift ditambah buffer.
+// Sesuai kebijakan, absensi ditutup otomatis pada jam akhir shift atau ditandai "Missing Checkout".
+func HandleMissingCheckouts(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		log.Printf("Failed to load timezone: %v\n", err)
+		return
+	}
+
+	now := time.Now().In(loc)
+	policy := missingCheckoutPolicy()
+	buffer := missingCheckoutBuffer()
+
+	var attendances []models.Attendance
+	db.Where("status IN ? AND in_time <> ? AND out_time = ? AND attendance_date <= ?", []string{"Present", "Half Day"}, "", "", now.Format("2006-01-02")).Find(&attendances)
+
+	for _, attendance := range attendances {
+		var employee models.Employee
+		if err := db.First(&employee, attendance.EmployeeID).Error; err != nil {
+			log.Printf("Failed to fetch employee %d for attendance %d: %v\n", attendance.EmployeeID, attendance.ID, err)
+			continue
+		}
+
+		attendanceDate, err := time.ParseInLocation("2006-01-02", attendance.AttendanceDate, loc)
+		if err != nil {
+			continue
+		}
+
+		shiftInTime, shiftOutTime, err := getShiftForDay(db, getEffectiveShiftID(db, employee, attendance.AttendanceDate), attendanceDate.Weekday().String())
+		if err != nil {
+			log.Printf("Failed to fetch shift data for employee %s: %v\n", employee.Username, err)
+			continue
+		}
+
+		// Tanpa jam shift (hari istirahat) absensi dianggap berakhir di penghujung hari
+		shiftEnd := attendanceDate.AddDate(0, 0, 1)
+		if shiftOutTime != "" {
 			shiftOut, err := time.Parse("15:04:05", shiftOutTime)
 			if err != nil {
-				log.Printf("Failed to parse shift out time for employee %s: %v\n", employee.Username, err)
 				continue
 			}
+			shiftEnd = attendanceDate.Add(time.Duration(shiftOut.Hour())*time.Hour + time.Duration(shiftOut.Minute())*time.Minute + time.Duration(shiftOut.Second())*time.Second)
+			if shiftInTime != "" && shiftOutTime <= shiftInTime {
+				// Shift malam yang berakhir keesokan harinya
+				shiftEnd = shiftEnd.AddDate(0, 0, 1)
+			}
+		}
 
-			// Calculate late minutes
-			workDuration := shiftOut.Sub(shiftIn)
-			lateMinutes := int(workDuration.Minutes())
+		if now.Before(shiftEnd.Add(buffer)) {
+			continue
+		}
 
-			currentTime := time.Now()
-			attendance := models.Attendance{
-				EmployeeID:       employee.ID,
-				Username:         employee.Username,
-				FullNameEmployee: employee.FirstName + " " + employee.LastName,
-				AttendanceDate:   today,
-				InTime:           "",
-				OutTime:          "",
-				TotalWork:        "",
-				Status:           "Absent",
-				LateMinutes:      lateMinutes,
-				CreatedAt:        &currentTime,
+		auditLog := newAttendanceAuditLog(attendance, "AutoCheckout", 0)
+		isAutoClosed := policy == "auto_close" && shiftOutTime != "" && shiftOutTime > attendance.InTime
+		if isAutoClosed {
+			attendance.OutTime = shiftOutTime
+			if err := recalculateAttendance(db, employee, &attendance); err != nil {
+				log.Printf("Failed to recalculate attendance %d: %v\n", attendance.ID, err)
+				continue
 			}
-			db.Create(&attendance)
-			log.Printf("Marked employee %s as absent on %s with late minutes %d\n", employee.Username, today, lateMinutes)
+			attendance.IsAutoCheckout = true
+		} else {
+			attendance.Status = "Missing Checkout"
+		}
+
+		db.Save(&attendance)
+		completeAttendanceAuditLog(&auditLog, attendance, "System", 0, "System")
+		db.Create(&auditLog)
+		log.Printf("Processed missing checkout for employee %s on %s (%s)\n", employee.Username, attendance.AttendanceDate, attendance.Status)
+
+		err = helper.SendMissingCheckoutNotification(employee.Email, employee.FirstName+" "+employee.LastName, attendance.AttendanceDate, attendance.OutTime, isAutoClosed)
+		if err != nil {
+			fmt.Println("Failed to send missing checkout notification:", err)
+		}
+	}
+}
+
+type MarkAbsentRequest struct {
+	Date string `json:"date"`
+}
+
+// MarkAbsentEmployeesByAdmin menjalankan ulang proses penandaan absen untuk tanggal tertentu
+func MarkAbsentEmployeesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
 		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		var request MarkAbsentRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"})
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		// Hanya tanggal yang sudah lewat, hari ini ditangani job malam agar karyawan yang belum datang tidak ditandai absen
+		now := time.Now().In(loc)
+		today := now.Format("2006-01-02")
+		if request.Date == "" {
+			request.Date = now.AddDate(0, 0, -1).Format("2006-01-02")
+		}
+
+		if _, err := time.Parse("2006-01-02", request.Date); err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid date format. Required format: yyyy-mm-dd"})
+		}
+
+		if request.Date >= today {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Absence can only be marked for past dates"})
+		}
+
+		summary, err := MarkAbsentEmployeesForDate(db, request.Date)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to mark absent employees"})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Absence marking completed successfully",
+			"data":    summary,
+		})
 	}
 }
 
@@ -15,12 +15,24 @@ import (
 )
 
 func getShiftForDay(db *gorm.DB, shiftID uint, day string) (string, string, error) {
+	_, inTime, outTime, err := getShiftWithTimesForDay(db, shiftID, day)
+	return inTime, outTime, err
+}
+
+// getShiftWithTimesForDay mengembalikan shift beserta jam masuk/keluar untuk hari tersebut,
+// dipakai ketika aturan absensi shift (grace, pembulatan, istirahat) juga dibutuhkan
+func getShiftWithTimesForDay(db *gorm.DB, shiftID uint, day string) (models.Shift, string, string, error) {
 	var shift models.Shift
 	result := db.First(&shift, shiftID)
 	if result.Error != nil {
-		return "", "", result.Error
+		return shift, "", "", result.Error
 	}
 
+	inTime, outTime, err := shiftTimesForDay(shift, day)
+	return shift, inTime, outTime, err
+}
+
+func shiftTimesForDay(shift models.Shift, day string) (string, string, error) {
 	var inTime, outTime string
 	switch day {
 	case "Monday":
@@ -80,6 +92,155 @@ func calculateEarlyLeaving(shiftOutTime string, actualOutTime string) string {
 	return "0s"
 }
 
+// roundUpMinutes membulatkan menit ke atas ke kelipatan block (misal 5 atau 15 menit)
+func roundUpMinutes(minutes int, block int) int {
+	if block <= 0 || minutes <= 0 {
+		return minutes
+	}
+	if minutes%block == 0 {
+		return minutes
+	}
+	return (minutes/block + 1) * block
+}
+
+// applyLateRules menerapkan grace period dan pembulatan shift pada durasi keterlambatan
+func applyLateRules(shift models.Shift, lateDuration string) (string, int) {
+	lateMinutes := calculateLateMinutes(lateDuration)
+	if lateMinutes <= shift.LateGraceMinutes {
+		return "0s", 0
+	}
+	lateMinutes = roundUpMinutes(lateMinutes, shift.RoundingMinutes)
+	return (time.Duration(lateMinutes) * time.Minute).String(), lateMinutes
+}
+
+// applyEarlyLeavingRules menerapkan pembulatan shift pada durasi pulang lebih awal
+func applyEarlyLeavingRules(shift models.Shift, earlyLeavingDuration string) (string, int) {
+	earlyLeavingMinutes := calculateEarlyLeavingMinutes(earlyLeavingDuration)
+	if earlyLeavingMinutes <= 0 {
+		return "0s", 0
+	}
+	earlyLeavingMinutes = roundUpMinutes(earlyLeavingMinutes, shift.RoundingMinutes)
+	return (time.Duration(earlyLeavingMinutes) * time.Minute).String(), earlyLeavingMinutes
+}
+
+// calculateNetWork menghitung total kerja setelah dikurangi istirahat tidak dibayar
+func calculateNetWork(shift models.Shift, inTime time.Time, outTime time.Time) time.Duration {
+	workDuration := outTime.Sub(inTime)
+	breakDuration := time.Duration(shift.BreakMinutes) * time.Minute
+	if workDuration > breakDuration {
+		workDuration -= breakDuration
+	}
+	return workDuration
+}
+
+// attendanceStatusForWork menandai absensi sebagai "Half Day" bila total kerja di bawah ambang shift
+func attendanceStatusForWork(shift models.Shift, workDuration time.Duration) string {
+	if shift.HalfDayThresholdMinutes > 0 && int(workDuration.Minutes()) < shift.HalfDayThresholdMinutes {
+		return "Half Day"
+	}
+	return "Present"
+}
+
+// checkInEmployee mencatat check-in hari ini (waktu Jakarta) beserta perhitungan keterlambatan shift.
+// kioskID diisi jika check-in dilakukan melalui kiosk. Status HTTP dikembalikan bersama error.
+func checkInEmployee(db *gorm.DB, employee models.Employee, kioskID uint) (models.Attendance, int, error) {
+	// Load Jakarta timezone
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return models.Attendance{}, http.StatusInternalServerError, errors.New("Failed to load timezone")
+	}
+
+	today := time.Now().In(loc).Format("2006-01-02")
+	var existingAttendance models.Attendance
+	result := db.Where("employee_id = ? AND attendance_date = ?", employee.ID, today).First(&existingAttendance)
+	if result.Error == nil {
+		return models.Attendance{}, http.StatusBadRequest, errors.New("Employee has already checked in for today")
+	}
+
+	currentTime := time.Now().In(loc)
+	shift, shiftInTime, _, err := getShiftWithTimesForDay(db, getEffectiveShiftID(db, employee, today), currentTime.Weekday().String())
+	if err != nil {
+		return models.Attendance{}, http.StatusInternalServerError, errors.New("Failed to fetch shift data")
+	}
+
+	lateDuration, lateMinutes := applyLateRules(shift, calculateLate(shiftInTime, currentTime.Format("15:04:05")))
+
+	attendance := models.Attendance{
+		EmployeeID:       employee.ID,
+		Username:         employee.Username,
+		FullNameEmployee: employee.FirstName + " " + employee.LastName,
+		AttendanceDate:   today,
+		InTime:           currentTime.Format("15:04:05"),
+		Status:           "Present",
+		Late:             lateDuration,
+		LateMinutes:      lateMinutes,
+		KioskID:          kioskID,
+		CreatedAt:        &currentTime,
+	}
+	db.Create(&attendance)
+
+	err = helper.SendAttendanceCheckinNotification(employee.Email, employee.FirstName+" "+employee.LastName, attendance.InTime)
+	if err != nil {
+		// Handle error
+		fmt.Println("Failed to send check-in notification:", err)
+	}
+
+	return attendance, http.StatusOK, nil
+}
+
+// checkOutEmployee mencatat check-out hari ini (waktu Jakarta) beserta total kerja dan pulang lebih awal.
+// Status HTTP dikembalikan bersama error.
+func checkOutEmployee(db *gorm.DB, employee models.Employee) (models.Attendance, int, error) {
+	// Load Jakarta timezone
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return models.Attendance{}, http.StatusInternalServerError, errors.New("Failed to load timezone")
+	}
+
+	today := time.Now().In(loc).Format("2006-01-02")
+	var existingAttendance models.Attendance
+	result := db.Where("employee_id = ? AND attendance_date = ?", employee.ID, today).First(&existingAttendance)
+	if result.Error != nil {
+		return models.Attendance{}, http.StatusBadRequest, errors.New("Employee has not checked in for today")
+	}
+
+	if existingAttendance.Status == "Absent" {
+		return models.Attendance{}, http.StatusBadRequest, errors.New("Employee is absent for today")
+	}
+
+	if existingAttendance.OutTime != "" {
+		return models.Attendance{}, http.StatusBadRequest, errors.New("Employee has already checked out for today")
+	}
+
+	currentTime := time.Now().In(loc)
+	existingAttendance.OutTime = currentTime.Format("15:04:05")
+	inTime, _ := time.Parse("15:04:05", existingAttendance.InTime)
+	outTime, _ := time.Parse("15:04:05", existingAttendance.OutTime)
+
+	shift, _, shiftOutTime, err := getShiftWithTimesForDay(db, getEffectiveShiftID(db, employee, today), currentTime.Weekday().String())
+	if err != nil {
+		return models.Attendance{}, http.StatusInternalServerError, errors.New("Failed to fetch shift data")
+	}
+
+	totalWork := calculateNetWork(shift, inTime, outTime).Round(time.Minute)
+	existingAttendance.TotalWork = totalWork.String()
+	existingAttendance.Status = attendanceStatusForWork(shift, totalWork)
+
+	earlyLeavingDuration, earlyLeavingMinutes := applyEarlyLeavingRules(shift, calculateEarlyLeaving(shiftOutTime, currentTime.Format("15:04:05")))
+	existingAttendance.EarlyLeaving = earlyLeavingDuration
+	existingAttendance.EarlyLeavingMinutes = earlyLeavingMinutes
+
+	db.Save(&existingAttendance)
+
+	err = helper.SendAttendanceCheckoutNotification(employee.Email, employee.FirstName+" "+employee.LastName, existingAttendance.OutTime, existingAttendance.TotalWork)
+	if err != nil {
+		// Handle error
+		fmt.Println("Failed to send checkout notification:", err)
+	}
+
+	return existingAttendance, http.StatusOK, nil
+}
+
 func EmployeeCheckIn(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		tokenString := c.Request().Header.Get("Authorization")
@@ -109,49 +270,10 @@ func EmployeeCheckIn(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
-		// Load Jakarta timezone
-		loc, err := time.LoadLocation("Asia/Jakarta")
+		attendance, statusCode, err := checkInEmployee(db, employee, 0)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
-		}
-
-		today := time.Now().In(loc).Format("2006-01-02")
-		var existingAttendance models.Attendance
-		result = db.Where("employee_id = ? AND attendance_date = ?", employee.ID, today).First(&existingAttendance)
-		if result.Error == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Employee has already checked in for today"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
-		}
-
-		employee.FullName = employee.FirstName + " " + employee.LastName
-
-		currentTime := time.Now().In(loc)
-		shiftInTime, _, err := getShiftForDay(db, employee.ShiftID, currentTime.Weekday().String())
-		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"}
-			return c.JSON(http.StatusInternalServerError, errorResponse)
-		}
-
-		lateDuration := calculateLate(shiftInTime, currentTime.Format("15:04:05"))
-		lateMinutes := calculateLateMinutes(lateDuration)
-
-		attendance := models.Attendance{
-			EmployeeID:       employee.ID,
-			Username:         employee.Username,
-			FullNameEmployee: employee.FirstName + " " + employee.LastName,
-			AttendanceDate:   today,
-			InTime:           currentTime.Format("15:04:05"),
-			Status:           "Present",
-			Late:             lateDuration,
-			LateMinutes:      lateMinutes,
-			CreatedAt:        &currentTime,
-		}
-		db.Create(&attendance)
-
-		err = helper.SendAttendanceCheckinNotification(employee.Email, employee.FirstName+" "+employee.LastName, attendance.InTime)
-		if err != nil {
-			// Handle error
-			fmt.Println("Failed to send check-in notification:", err)
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -343,64 +465,25 @@ func EmployeeCheckOut(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
-		// Load Jakarta timezone
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
-		}
-
-		today := time.Now().In(loc).Format("2006-01-02")
-		var existingAttendance models.Attendance
-		result = db.Where("employee_id = ? AND attendance_date = ?", employee.ID, today).First(&existingAttendance)
-		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Employee has not checked in for today"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
-		}
-
-		if existingAttendance.Status == "Absent" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Employee is absent for today"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
-		}
-
-		if existingAttendance.OutTime != "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Employee has already checked out for today"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
-		}
-
-		currentTime := time.Now().In(loc)
-		existingAttendance.OutTime = currentTime.Format("15:04:05")
-		inTime, _ := time.Parse("15:04:05", existingAttendance.InTime)
-		outTime, _ := time.Parse("15:04:05", existingAttendance.OutTime)
-		totalWork := outTime.Sub(inTime).Round(time.Minute)
-		existingAttendance.TotalWork = totalWork.String()
-
-		_, shiftOutTime, err := getShiftForDay(db, employee.ShiftID, currentTime.Weekday().String())
-		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"}
-			return c.JSON(http.StatusInternalServerError, errorResponse)
-		}
-
-		earlyLeavingDuration := calculateEarlyLeaving(shiftOutTime, currentTime.Format("15:04:05"))
-		existingAttendance.EarlyLeaving = earlyLeavingDuration
-		earlyLeavingMinutes := calculateEarlyLeavingMinutes(earlyLeavingDuration)
-		existingAttendance.EarlyLeavingMinutes = earlyLeavingMinutes
-
-		db.Save(&existingAttendance)
-
-		err = helper.SendAttendanceCheckoutNotification(employee.Email, employee.FirstName+" "+employee.LastName, existingAttendance.OutTime, existingAttendance.TotalWork)
+		existingAttendance, statusCode, err := checkOutEmployee(db, employee)
 		if err != nil {
-			// Handle error
-			fmt.Println("Failed to send checkout notification:", err)
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
 		}
 
-		return c.JSON(http.StatusOK, map[string]interface{}{
+		response := map[string]interface{}{
 			"code":          http.StatusOK,
 			"error":         false,
 			"message":       "Employee check-out successful",
 			"time":          existingAttendance.OutTime,
 			"total_work":    existingAttendance.TotalWork,
 			"early_leaving": existingAttendance.EarlyLeaving,
-		})
+		}
+		if suggestion, ok := suggestOvertime(db, employee, existingAttendance); ok {
+			response["suggested_overtime"] = suggestion
+		}
+
+		return c.JSON(http.StatusOK, response)
 	}
 }
 
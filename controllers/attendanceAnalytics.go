@@ -0,0 +1,713 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type employeeAttendanceStats struct {
+	EmployeeID     uint    `json:"employee_id"`
+	FullName       string  `json:"full_name"`
+	DepartmentID   uint    `json:"department_id"`
+	DepartmentName string  `json:"department_name"`
+	Attendances    int     `json:"attendances"`
+	LateCount      int     `json:"late_count"`
+	LateMinutes    int     `json:"late_minutes"`
+	AbsentDays     int     `json:"absent_days"`
+	AbsenceSpells  int     `json:"absence_spells"`
+	BradfordFactor float64 `json:"bradford_factor"`
+}
+
+type lateTrendPoint struct {
+	Period      string `json:"period"`
+	Attendances int    `json:"attendances"`
+	LateCount   int    `json:"late_count"`
+	LateMinutes int    `json:"late_minutes"`
+}
+
+// analyticsWindow membaca start_date dan end_date, default defaultDays hari terakhir sampai hari ini
+func analyticsWindow(c echo.Context, defaultDays int) (time.Time, time.Time, error) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	now := time.Now().In(loc)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if endDateStr := c.QueryParam("end_date"); endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid end date format. Required format: yyyy-mm-dd")
+		}
+	}
+
+	startDate := endDate.AddDate(0, 0, -(defaultDays - 1))
+	if startDateStr := c.QueryParam("start_date"); startDateStr != "" {
+		startDate, err = time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid start date format. Required format: yyyy-mm-dd")
+		}
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, errors.New("End date must be after start date")
+	}
+	if endDate.Sub(startDate) > 366*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("Date range cannot exceed one year")
+	}
+
+	return startDate, endDate, nil
+}
+
+// fetchAnalyticsAttendances mengambil absensi karyawan non-client pada rentang tanggal, urut per karyawan dan tanggal
+func fetchAnalyticsAttendances(db *gorm.DB, startDate, endDate time.Time, departmentID int) ([]models.Attendance, map[uint]models.Employee, map[uint]string, error) {
+	var employees []models.Employee
+	query := db.Where("is_client = ?", false)
+	if departmentID > 0 {
+		query = query.Where("department_id = ?", departmentID)
+	}
+	if err := query.Find(&employees).Error; err != nil {
+		return nil, nil, nil, err
+	}
+
+	employeeByID := make(map[uint]models.Employee)
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeByID[employee.ID] = employee
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	var departments []models.Department
+	db.Find(&departments)
+	departmentNames := make(map[uint]string)
+	for _, department := range departments {
+		departmentNames[department.ID] = department.DepartmentName
+	}
+
+	var attendances []models.Attendance
+	if len(employeeIDs) > 0 {
+		err := db.Where("employee_id IN ? AND attendance_date BETWEEN ? AND ?", employeeIDs, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+			Order("employee_id ASC, attendance_date ASC").
+			Find(&attendances).Error
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return attendances, employeeByID, departmentNames, nil
+}
+
+// attendanceLateMinutes membaca keterlambatan dari kolom Late karena late_minutes direset ke 0 setiap payroll dijalankan
+func attendanceLateMinutes(attendance models.Attendance) int {
+	if attendance.Late != "" {
+		if duration, err := time.ParseDuration(attendance.Late); err == nil {
+			return int(duration.Minutes())
+		}
+	}
+	return attendance.LateMinutes
+}
+
+// attendanceEarlyLeavingMinutes membaca pulang cepat dari kolom EarlyLeaving karena early_leaving_minutes juga direset saat payroll
+func attendanceEarlyLeavingMinutes(attendance models.Attendance) int {
+	if attendance.EarlyLeaving != "" {
+		if duration, err := time.ParseDuration(attendance.EarlyLeaving); err == nil {
+			return int(duration.Minutes())
+		}
+	}
+	return attendance.EarlyLeavingMinutes
+}
+
+// calculateAttendanceStats menghitung keterlambatan dan Bradford factor (S x S x D) per karyawan.
+// Satu spell adalah rangkaian record "Absent" berturut-turut; hari istirahat tanpa record tidak memutus spell.
+func calculateAttendanceStats(db *gorm.DB, startDate, endDate time.Time, departmentID int) (map[uint]*employeeAttendanceStats, error) {
+	attendances, employeeByID, departmentNames, err := fetchAnalyticsAttendances(db, startDate, endDate, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[uint]*employeeAttendanceStats)
+	for _, employee := range employeeByID {
+		stats[employee.ID] = &employeeAttendanceStats{
+			EmployeeID:     employee.ID,
+			FullName:       employee.FirstName + " " + employee.LastName,
+			DepartmentID:   employee.DepartmentID,
+			DepartmentName: departmentNames[employee.DepartmentID],
+		}
+	}
+
+	previousAbsent := make(map[uint]bool)
+	for _, attendance := range attendances {
+		stat := stats[attendance.EmployeeID]
+		if stat == nil {
+			continue
+		}
+
+		stat.Attendances++
+		if attendance.Status == "Absent" {
+			stat.AbsentDays++
+			if !previousAbsent[attendance.EmployeeID] {
+				stat.AbsenceSpells++
+			}
+			previousAbsent[attendance.EmployeeID] = true
+			continue
+		}
+		previousAbsent[attendance.EmployeeID] = false
+
+		if lateMinutes := attendanceLateMinutes(attendance); lateMinutes > 0 {
+			stat.LateCount++
+			stat.LateMinutes += lateMinutes
+		}
+	}
+
+	for _, stat := range stats {
+		stat.BradfordFactor = float64(stat.AbsenceSpells * stat.AbsenceSpells * stat.AbsentDays)
+	}
+
+	return stats, nil
+}
+
+// analyticsPeriod mengelompokkan tanggal ke minggu (Senin) atau bulan
+func analyticsPeriod(date time.Time, interval string) string {
+	if interval == "month" {
+		return date.Format("2006-01")
+	}
+	offsetToMonday := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offsetToMonday).Format("2006-01-02")
+}
+
+// GetLateTrendsByAdmin menampilkan tren keterlambatan per karyawan atau departemen per minggu/bulan
+func GetLateTrendsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		startDate, endDate, err := analyticsWindow(c, 90)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		departmentID, _ := strconv.Atoi(c.QueryParam("department_id"))
+
+		interval := c.QueryParam("interval")
+		if interval == "" {
+			interval = "week"
+		}
+		if interval != "week" && interval != "month" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interval must be week or month"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		groupBy := c.QueryParam("group_by")
+		if groupBy == "" {
+			groupBy = "department"
+		}
+		if groupBy != "department" && groupBy != "employee" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "group_by must be department or employee"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		employeeID, _ := strconv.Atoi(c.QueryParam("employee_id"))
+
+		attendances, employeeByID, departmentNames, err := fetchAnalyticsAttendances(db, startDate, endDate, departmentID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		type trendGroup struct {
+			ID     uint   `json:"id"`
+			Name   string `json:"name"`
+			points map[string]*lateTrendPoint
+			Trend  []*lateTrendPoint `json:"trend"`
+		}
+
+		groups := make(map[uint]*trendGroup)
+		for _, attendance := range attendances {
+			if attendance.Status == "Absent" || attendance.Status == "On Leave" {
+				continue
+			}
+			if employeeID > 0 && attendance.EmployeeID != uint(employeeID) {
+				continue
+			}
+
+			employee := employeeByID[attendance.EmployeeID]
+			groupID := employee.DepartmentID
+			groupName := departmentNames[employee.DepartmentID]
+			if groupBy == "employee" {
+				groupID = employee.ID
+				groupName = employee.FirstName + " " + employee.LastName
+			}
+
+			group, ok := groups[groupID]
+			if !ok {
+				group = &trendGroup{ID: groupID, Name: groupName, points: make(map[string]*lateTrendPoint)}
+				groups[groupID] = group
+			}
+
+			date, err := time.Parse("2006-01-02", attendance.AttendanceDate)
+			if err != nil {
+				continue
+			}
+			period := analyticsPeriod(date, interval)
+			point, ok := group.points[period]
+			if !ok {
+				point = &lateTrendPoint{Period: period}
+				group.points[period] = point
+			}
+
+			point.Attendances++
+			if lateMinutes := attendanceLateMinutes(attendance); lateMinutes > 0 {
+				point.LateCount++
+				point.LateMinutes += lateMinutes
+			}
+		}
+
+		data := make([]*trendGroup, 0, len(groups))
+		for _, group := range groups {
+			for _, point := range group.points {
+				group.Trend = append(group.Trend, point)
+			}
+			sort.Slice(group.Trend, func(i, j int) bool { return group.Trend[i].Period < group.Trend[j].Period })
+			data = append(data, group)
+		}
+		sort.Slice(data, func(i, j int) bool { return data[i].Name < data[j].Name })
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Late trends retrieved successfully",
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"interval":   interval,
+			"group_by":   groupBy,
+			"data":       data,
+		})
+	}
+}
+
+// GetBradfordFactorByAdmin menampilkan skor Bradford factor absensi per karyawan (default 52 minggu terakhir)
+func GetBradfordFactorByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		startDate, endDate, err := analyticsWindow(c, 364)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		departmentID, _ := strconv.Atoi(c.QueryParam("department_id"))
+
+		stats, err := calculateAttendanceStats(db, startDate, endDate, departmentID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to calculate Bradford factor"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		data := sortedAttendanceStats(stats, "bradford_factor")
+		totalCount := len(data)
+		offset := (page - 1) * perPage
+		if offset > totalCount {
+			offset = totalCount
+		}
+		end := offset + perPage
+		if end > totalCount {
+			end = totalCount
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Bradford factor scores retrieved successfully",
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"data":       data[offset:end],
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+// sortedAttendanceStats mengurutkan statistik karyawan dari nilai metric terbesar
+func sortedAttendanceStats(stats map[uint]*employeeAttendanceStats, metric string) []*employeeAttendanceStats {
+	value := func(stat *employeeAttendanceStats) float64 {
+		switch metric {
+		case "late_count":
+			return float64(stat.LateCount)
+		case "late_minutes":
+			return float64(stat.LateMinutes)
+		case "absent_days":
+			return float64(stat.AbsentDays)
+		default:
+			return stat.BradfordFactor
+		}
+	}
+
+	data := make([]*employeeAttendanceStats, 0, len(stats))
+	for _, stat := range stats {
+		data = append(data, stat)
+	}
+	sort.Slice(data, func(i, j int) bool {
+		if value(data[i]) != value(data[j]) {
+			return value(data[i]) > value(data[j])
+		}
+		return data[i].EmployeeID < data[j].EmployeeID
+	})
+	return data
+}
+
+// GetTopAttendanceOffendersByAdmin menampilkan top-N karyawan berdasarkan metric keterlambatan atau absensi
+func GetTopAttendanceOffendersByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		startDate, endDate, err := analyticsWindow(c, 30)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		departmentID, _ := strconv.Atoi(c.QueryParam("department_id"))
+
+		metric := c.QueryParam("metric")
+		if metric == "" {
+			metric = "late_minutes"
+		}
+		if metric != "late_minutes" && metric != "late_count" && metric != "absent_days" && metric != "bradford_factor" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Metric must be late_minutes, late_count, absent_days or bradford_factor"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		if limit > 100 {
+			limit = 100
+		}
+
+		stats, err := calculateAttendanceStats(db, startDate, endDate, departmentID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to calculate attendance statistics"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		data := sortedAttendanceStats(stats, metric)
+		// Karyawan dengan nilai nol tidak perlu ditampilkan
+		top := make([]*employeeAttendanceStats, 0, limit)
+		for _, stat := range data {
+			if len(top) == limit {
+				break
+			}
+			if metric == "late_minutes" && stat.LateMinutes == 0 || metric == "late_count" && stat.LateCount == 0 ||
+				metric == "absent_days" && stat.AbsentDays == 0 || metric == "bradford_factor" && stat.BradfordFactor == 0 {
+				break
+			}
+			top = append(top, stat)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Top attendance offenders retrieved successfully",
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"metric":     metric,
+			"data":       top,
+		})
+	}
+}
+
+func GetAttendanceFlagsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.AttendanceFlag{})
+		if flagType := c.QueryParam("flag_type"); flagType != "" {
+			query = query.Where("flag_type = ?", flagType)
+		}
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("full_name_employee ILIKE ? OR department_name ILIKE ?", searchPattern, searchPattern)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var flags []models.AttendanceFlag
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&flags).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance flags"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance flags retrieved successfully",
+			"data":    flags,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+// analyticsThreshold membaca ambang dari env, dengan nilai default jika kosong atau tidak valid
+func analyticsThreshold(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// FlagAttendanceIssues dijalankan mingguan: menandai karyawan yang sering terlambat dalam 30 hari terakhir
+// (ATTENDANCE_LATE_COUNT_THRESHOLD, default 5) atau memiliki Bradford factor 52 minggu terakhir di atas
+// ATTENDANCE_BRADFORD_THRESHOLD (default 250), lalu mengirim ringkasan ke semua admin HR.
+func FlagAttendanceIssues(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		log.Printf("Failed to load timezone: %v\n", err)
+		return
+	}
+
+	now := time.Now().In(loc)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	lateThreshold := analyticsThreshold("ATTENDANCE_LATE_COUNT_THRESHOLD", 5)
+	bradfordThreshold := analyticsThreshold("ATTENDANCE_BRADFORD_THRESHOLD", 250)
+
+	lateStart := endDate.AddDate(0, 0, -29)
+	lateStats, err := calculateAttendanceStats(db, lateStart, endDate, 0)
+	if err != nil {
+		log.Printf("Failed to calculate lateness statistics: %v\n", err)
+		return
+	}
+
+	bradfordStart := endDate.AddDate(0, 0, -363)
+	bradfordStats, err := calculateAttendanceStats(db, bradfordStart, endDate, 0)
+	if err != nil {
+		log.Printf("Failed to calculate Bradford factor: %v\n", err)
+		return
+	}
+
+	var flags []models.AttendanceFlag
+	for _, stat := range sortedAttendanceStats(lateStats, "late_count") {
+		if float64(stat.LateCount) < lateThreshold {
+			break
+		}
+		flags = append(flags, newAttendanceFlag(stat, "Chronic Lateness", float64(stat.LateCount), lateThreshold, lateStart, endDate))
+	}
+	for _, stat := range sortedAttendanceStats(bradfordStats, "bradford_factor") {
+		if stat.BradfordFactor < bradfordThreshold {
+			break
+		}
+		flags = append(flags, newAttendanceFlag(stat, "Bradford Factor", stat.BradfordFactor, bradfordThreshold, bradfordStart, endDate))
+	}
+
+	var items []helper.AttendanceFlagEmailItem
+	for _, flag := range flags {
+		// Job aman dijalankan ulang untuk periode yang sama
+		var existing models.AttendanceFlag
+		if db.Where("employee_id = ? AND flag_type = ? AND period_end = ?", flag.EmployeeID, flag.FlagType, flag.PeriodEnd).First(&existing).Error == nil {
+			continue
+		}
+		db.Create(&flag)
+
+		items = append(items, helper.AttendanceFlagEmailItem{
+			FullName:       flag.FullNameEmployee,
+			DepartmentName: flag.DepartmentName,
+			FlagType:       flag.FlagType,
+			Value:          strconv.FormatFloat(flag.Value, 'f', 0, 64),
+			Threshold:      strconv.FormatFloat(flag.Threshold, 'f', 0, 64),
+		})
+	}
+
+	log.Printf("Attendance flag job finished: %d employees flagged\n", len(items))
+	if len(items) == 0 {
+		return
+	}
+
+	var admins []models.Admin
+	db.Where("is_admin_hr = ?", true).Find(&admins)
+	for _, admin := range admins {
+		err := helper.SendAttendanceFlagNotification(admin.Email, admin.FirstName+" "+admin.LastName, lateStart.Format("2006-01-02"), endDate.Format("2006-01-02"), items)
+		if err != nil {
+			fmt.Println("Failed to send attendance flag notification:", err)
+		}
+	}
+}
+
+func newAttendanceFlag(stat *employeeAttendanceStats, flagType string, value, threshold float64, startDate, endDate time.Time) models.AttendanceFlag {
+	currentTime := time.Now()
+	return models.AttendanceFlag{
+		EmployeeID:       stat.EmployeeID,
+		FullNameEmployee: stat.FullName,
+		DepartmentID:     stat.DepartmentID,
+		DepartmentName:   stat.DepartmentName,
+		FlagType:         flagType,
+		Value:            value,
+		Threshold:        threshold,
+		PeriodStart:      startDate.Format("2006-01-02"),
+		PeriodEnd:        endDate.Format("2006-01-02"),
+		CreatedAt:        &currentTime,
+	}
+}
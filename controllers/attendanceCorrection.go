@@ -0,0 +1,323 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidCorrectionTimes = errors.New("out time must be after in time")
+
+type ReviewAttendanceCorrectionRequest struct {
+	Status     string `json:"status"`
+	ReviewNote string `json:"review_note"`
+}
+
+// isDepartmentHeadOf mengecek apakah managerID merupakan kepala departemen dari karyawan tersebut
+func isDepartmentHeadOf(db *gorm.DB, managerID uint, employee models.Employee) bool {
+	if employee.ID == managerID {
+		return false
+	}
+	var count int64
+	db.Model(&models.Department{}).Where("id = ? AND employee_id = ?", employee.DepartmentID, managerID).Count(&count)
+	return count > 0
+}
+
+// correctedAttendanceTimes menggabungkan jam yang diminta dengan absensi yang sudah ada
+func correctedAttendanceTimes(attendance models.Attendance, correction models.AttendanceCorrection) (string, string, error) {
+	inTime := attendance.InTime
+	outTime := attendance.OutTime
+	if correction.RequestedInTime != "" {
+		inTime = correction.RequestedInTime
+	}
+	if correction.RequestedOutTime != "" {
+		outTime = correction.RequestedOutTime
+	}
+
+	if inTime == "" {
+		return "", "", errors.New("in time is required because there is no check-in for this date")
+	}
+
+	parsedIn, err := time.Parse("15:04:05", inTime)
+	if err != nil {
+		return "", "", err
+	}
+	if outTime != "" {
+		parsedOut, err := time.Parse("15:04:05", outTime)
+		if err != nil {
+			return "", "", err
+		}
+		if !parsedOut.After(parsedIn) {
+			return "", "", errInvalidCorrectionTimes
+		}
+	}
+
+	return inTime, outTime, nil
+}
+
+// reviewAttendanceCorrection menyetujui atau menolak koreksi absensi. Jika disetujui, absensi
+// diperbarui, dihitung ulang dan nilai lamanya disimpan di audit log.
+func reviewAttendanceCorrection(db *gorm.DB, correction *models.AttendanceCorrection, request ReviewAttendanceCorrectionRequest, role string, reviewerID uint, reviewerName string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if request.Status == "Approved" {
+			var employee models.Employee
+			if err := tx.First(&employee, correction.EmployeeID).Error; err != nil {
+				return err
+			}
+
+			var attendance models.Attendance
+			result := tx.Where("employee_id = ? AND attendance_date = ?", correction.EmployeeID, correction.AttendanceDate).First(&attendance)
+			if result.Error != nil {
+				attendance = models.Attendance{
+					EmployeeID:       employee.ID,
+					Username:         employee.Username,
+					FullNameEmployee: employee.FirstName + " " + employee.LastName,
+					AttendanceDate:   correction.AttendanceDate,
+				}
+			} else if attendance.Status == "Absent" {
+				// Record absen otomatis tidak memiliki jam masuk
+				attendance.InTime = ""
+				attendance.OutTime = ""
+			}
+
+			auditLog := newAttendanceAuditLog(attendance, "Correction", correction.ID)
+
+			inTime, outTime, err := correctedAttendanceTimes(attendance, *correction)
+			if err != nil {
+				return err
+			}
+			attendance.InTime = inTime
+			attendance.OutTime = outTime
+
+			if err := recalculateAttendance(tx, employee, &attendance); err != nil {
+				return err
+			}
+
+			if attendance.ID == 0 {
+				currentTime := time.Now()
+				attendance.CreatedAt = &currentTime
+			}
+			if err := tx.Save(&attendance).Error; err != nil {
+				return err
+			}
+
+			completeAttendanceAuditLog(&auditLog, attendance, role, reviewerID, reviewerName)
+			if err := tx.Create(&auditLog).Error; err != nil {
+				return err
+			}
+
+			correction.AttendanceID = attendance.ID
+		}
+
+		correction.Status = request.Status
+		correction.ReviewNote = request.ReviewNote
+		correction.ReviewerRole = role
+		correction.ReviewerID = reviewerID
+		correction.ReviewerName = reviewerName
+		return tx.Save(correction).Error
+	})
+}
+
+// notifyAttendanceCorrectionReviewed mengirim email hasil review koreksi absensi ke karyawan
+func notifyAttendanceCorrectionReviewed(db *gorm.DB, correction models.AttendanceCorrection) {
+	var employee models.Employee
+	if err := db.First(&employee, correction.EmployeeID).Error; err != nil {
+		fmt.Println("Failed to fetch employee for attendance correction notification:", err)
+		return
+	}
+
+	err := helper.SendAttendanceCorrectionStatusNotification(employee.Email, employee.FirstName+" "+employee.LastName, correction.AttendanceDate, correction.RequestedInTime, correction.RequestedOutTime, correction.Status, correction.ReviewNote)
+	if err != nil {
+		fmt.Println("Failed to send attendance correction notification:", err)
+	}
+}
+
+func GetAllAttendanceCorrectionsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.AttendanceCorrection{})
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("full_name_employee ILIKE ? OR attendance_date ILIKE ? OR reason ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var corrections []models.AttendanceCorrection
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&corrections).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance corrections"})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance corrections retrieved successfully",
+			"data":    corrections,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func ReviewAttendanceCorrectionByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		var correction models.AttendanceCorrection
+		result = db.First(&correction, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attendance correction not found"})
+		}
+
+		var request ReviewAttendanceCorrectionRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"})
+		}
+
+		if request.Status != "Approved" && request.Status != "Rejected" {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Status must be Approved or Rejected"})
+		}
+
+		if correction.Status != "Pending" {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Attendance correction has already been reviewed"})
+		}
+
+		if err := reviewAttendanceCorrection(db, &correction, request, "Admin", adminUser.ID, adminUser.FirstName+" "+adminUser.LastName); err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Failed to apply attendance correction: " + err.Error()})
+		}
+
+		notifyAttendanceCorrectionReviewed(db, correction)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance correction reviewed successfully",
+			"data":    correction,
+		})
+	}
+}
+
+func GetAttendanceAuditLogsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		var attendance models.Attendance
+		result = db.First(&attendance, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attendance not found"})
+		}
+
+		var auditLogs []models.AttendanceAuditLog
+		db.Where("attendance_id = ?", attendance.ID).Order("id DESC").Find(&auditLogs)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance audit logs retrieved successfully",
+			"data":    auditLogs,
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func CreateAttendanceCorrectionByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var correction models.AttendanceCorrection
+		if err := c.Bind(&correction); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if correction.AttendanceDate == "" || correction.Reason == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Attendance date and reason are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if correction.RequestedInTime == "" && correction.RequestedOutTime == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Requested in time or out time is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if _, err := time.Parse("2006-01-02", correction.AttendanceDate); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid attendance date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		if correction.AttendanceDate > time.Now().In(loc).Format("2006-01-02") {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot request a correction for a future date"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var pendingCount int64
+		db.Model(&models.AttendanceCorrection{}).Where("employee_id = ? AND attendance_date = ? AND status = ?", employee.ID, correction.AttendanceDate, "Pending").Count(&pendingCount)
+		if pendingCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "A pending correction for this date already exists"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		var attendance models.Attendance
+		result = db.Where("employee_id = ? AND attendance_date = ?", employee.ID, correction.AttendanceDate).First(&attendance)
+		if result.Error == nil {
+			if attendance.Status == "On Leave" {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot correct attendance on a leave day"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if attendance.Status == "Absent" {
+				attendance.InTime = ""
+				attendance.OutTime = ""
+			}
+			correction.AttendanceID = attendance.ID
+		}
+
+		if _, _, err := correctedAttendanceTimes(attendance, correction); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid requested time: " + err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		correction.EmployeeID = employee.ID
+		correction.Username = employee.Username
+		correction.FullNameEmployee = employee.FirstName + " " + employee.LastName
+		correction.Status = "Pending"
+		correction.ReviewerRole = ""
+		correction.ReviewerID = 0
+		correction.ReviewerName = ""
+		correction.ReviewNote = ""
+		correction.CreatedAt = &currentTime
+
+		db.Create(&correction)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Attendance correction request created successfully",
+			"data":    correction,
+		})
+	}
+}
+
+func GetAllAttendanceCorrectionsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.AttendanceCorrection{}).Where("employee_id = ?", employee.ID)
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var corrections []models.AttendanceCorrection
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&corrections).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance corrections"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance corrections retrieved successfully",
+			"data":    corrections,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func DeleteAttendanceCorrectionByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var correction models.AttendanceCorrection
+		result = db.Where("id = ? AND employee_id = ?", c.Param("id"), employee.ID).First(&correction)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attendance correction not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if correction.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only pending attendance corrections can be deleted"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		db.Delete(&correction)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance correction deleted successfully",
+		})
+	}
+}
+
+// GetTeamAttendanceCorrectionsByEmployee menampilkan koreksi absensi anggota departemen yang dipimpin karyawan (manager)
+func GetTeamAttendanceCorrectionsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		var departmentIDs []uint
+		db.Model(&models.Department{}).Where("employee_id = ?", employee.ID).Pluck("id", &departmentIDs)
+		if len(departmentIDs) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Only department managers can view team attendance corrections"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var teamIDs []uint
+		db.Model(&models.Employee{}).Where("department_id IN ? AND id <> ?", departmentIDs, employee.ID).Pluck("id", &teamIDs)
+
+		query := db.Model(&models.AttendanceCorrection{}).Where("employee_id IN ?", teamIDs)
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		var corrections []models.AttendanceCorrection
+		if len(teamIDs) > 0 {
+			query.Count(&totalCount)
+			if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&corrections).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance corrections"}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Team attendance corrections retrieved successfully",
+			"data":    corrections,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+// ReviewAttendanceCorrectionByManager dipakai kepala departemen untuk menyetujui koreksi absensi anggotanya
+func ReviewAttendanceCorrectionByManager(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var correction models.AttendanceCorrection
+		result = db.First(&correction, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attendance correction not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requester models.Employee
+		if err := db.First(&requester, correction.EmployeeID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !isDepartmentHeadOf(db, employee.ID, requester) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request ReviewAttendanceCorrectionRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Status != "Approved" && request.Status != "Rejected" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Status must be Approved or Rejected"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if correction.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Attendance correction has already been reviewed"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if err := reviewAttendanceCorrection(db, &correction, request, "Manager", employee.ID, employee.FirstName+" "+employee.LastName); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Failed to apply attendance correction: " + err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		notifyAttendanceCorrectionReviewed(db, correction)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Attendance correction reviewed successfully",
+			"data":    correction,
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kode status absensi pada grid timesheet
+var timesheetStatusCodes = map[string]string{
+	"Present":          "P",
+	"Half Day":         "HD",
+	"Missing Checkout": "MC",
+	"Absent":           "A",
+	"On Leave":         "L",
+}
+
+type timesheetRow struct {
+	EmployeeID          uint
+	Username            string
+	FullName            string
+	Codes               []string
+	Present             int
+	Absent              int
+	Leave               int
+	LateMinutes         int
+	EarlyLeavingMinutes int
+	OvertimeMinutes     int
+}
+
+type timesheetSheet struct {
+	DepartmentName string
+	Rows           []timesheetRow
+}
+
+// buildAttendanceTimesheet menyusun grid absensi per departemen untuk satu bulan
+func buildAttendanceTimesheet(db *gorm.DB, startDate time.Time, departmentID uint) ([]timesheetSheet, error) {
+	endDate := startDate.AddDate(0, 1, -1)
+	start := startDate.Format("2006-01-02")
+	end := endDate.Format("2006-01-02")
+	days := endDate.Day()
+
+	var departments []models.Department
+	departmentQuery := db.Order("department_name ASC")
+	if departmentID != 0 {
+		departmentQuery = departmentQuery.Where("id = ?", departmentID)
+	}
+	if err := departmentQuery.Find(&departments).Error; err != nil {
+		return nil, err
+	}
+
+	var employees []models.Employee
+	employeeQuery := db.Where("is_client = ?", false).Order("first_name ASC")
+	if departmentID != 0 {
+		employeeQuery = employeeQuery.Where("department_id = ?", departmentID)
+	}
+	if err := employeeQuery.Find(&employees).Error; err != nil {
+		return nil, err
+	}
+
+	var attendances []models.Attendance
+	if err := db.Where("attendance_date BETWEEN ? AND ?", start, end).Find(&attendances).Error; err != nil {
+		return nil, err
+	}
+	attendanceByEmployee := make(map[uint]map[string]models.Attendance)
+	for _, attendance := range attendances {
+		if attendanceByEmployee[attendance.EmployeeID] == nil {
+			attendanceByEmployee[attendance.EmployeeID] = make(map[string]models.Attendance)
+		}
+		attendanceByEmployee[attendance.EmployeeID][attendance.AttendanceDate] = attendance
+	}
+
+	var overtimes []models.OvertimeRequest
+	db.Where("status = ? AND date BETWEEN ? AND ?", "Accepted", start, end).Find(&overtimes)
+	// total_minutes direset ke 0 setelah payroll dibayar, durasi dihitung dari jam masuk/keluar lembur
+	overtimeByEmployee := make(map[uint]int)
+	for _, overtime := range overtimes {
+		overtimeByEmployee[overtime.EmployeeID] += int(overtimeDurationMinutes(overtime))
+	}
+
+	var holidays []models.Holiday
+	db.Where("holiday_date BETWEEN ? AND ?", start, end).Find(&holidays)
+	holidayDates := make(map[string]bool)
+	for _, holiday := range holidays {
+		holidayDates[holiday.HolidayDate] = true
+	}
+
+	sheetIndex := make(map[uint]int)
+	sheets := make([]timesheetSheet, 0, len(departments)+1)
+	for _, department := range departments {
+		sheetIndex[department.ID] = len(sheets)
+		sheets = append(sheets, timesheetSheet{DepartmentName: department.DepartmentName})
+	}
+
+	for _, employee := range employees {
+		row := timesheetRow{
+			EmployeeID: employee.ID,
+			Username:   employee.Username,
+			FullName:   employee.FirstName + " " + employee.LastName,
+			Codes:      make([]string, days),
+		}
+
+		for day := 1; day <= days; day++ {
+			date := startDate.AddDate(0, 0, day-1).Format("2006-01-02")
+			attendance, ok := attendanceByEmployee[employee.ID][date]
+			if !ok {
+				if holidayDates[date] {
+					row.Codes[day-1] = "H"
+				}
+				continue
+			}
+
+			row.Codes[day-1] = timesheetStatusCodes[attendance.Status]
+			switch attendance.Status {
+			case "Absent":
+				row.Absent++
+			case "On Leave":
+				row.Leave++
+			default:
+				row.Present++
+			}
+			row.LateMinutes += attendanceLateMinutes(attendance)
+			row.EarlyLeavingMinutes += attendanceEarlyLeavingMinutes(attendance)
+		}
+		row.OvertimeMinutes = overtimeByEmployee[employee.ID]
+
+		index, ok := sheetIndex[employee.DepartmentID]
+		if !ok {
+			// Karyawan tanpa departemen dikumpulkan dalam satu sheet
+			sheetIndex[employee.DepartmentID] = len(sheets)
+			index = len(sheets)
+			sheets = append(sheets, timesheetSheet{DepartmentName: "No Department"})
+		}
+		sheets[index].Rows = append(sheets[index].Rows, row)
+	}
+
+	return sheets, nil
+}
+
+// timesheetSheetName membersihkan nama departemen agar valid sebagai nama sheet Excel
+func timesheetSheetName(name string, used map[string]bool) string {
+	name = strings.NewReplacer(":", " ", "\\", " ", "/", " ", "?", " ", "*", " ", "[", " ", "]", " ").Replace(name)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Department"
+	}
+	// Batas 31 karakter dihitung per rune agar karakter multi-byte tidak terpotong
+	runes := []rune(name)
+	if len(runes) > 31 {
+		runes = runes[:31]
+		name = string(runes)
+	}
+
+	sheetName := name
+	for i := 2; used[strings.ToLower(sheetName)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		if len(runes)+len(suffix) > 31 {
+			sheetName = string(runes[:31-len(suffix)]) + suffix
+		} else {
+			sheetName = name + suffix
+		}
+	}
+	used[strings.ToLower(sheetName)] = true
+	return sheetName
+}
+
+func timesheetHeader(days int) []interface{} {
+	header := []interface{}{"No", "Employee ID", "Username", "Full Name"}
+	for day := 1; day <= days; day++ {
+		header = append(header, day)
+	}
+	return append(header, "Present", "Absent", "Leave", "Late Minutes", "Early Leaving Minutes", "Overtime Minutes")
+}
+
+func writeAttendanceTimesheetXLSX(sheets []timesheetSheet, startDate time.Time) (*bytes.Buffer, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	days := startDate.AddDate(0, 1, -1).Day()
+	usedNames := make(map[string]bool)
+	legend := "P = Present, HD = Half Day, MC = Missing Checkout, A = Absent, L = On Leave, H = Holiday"
+
+	headerStyle, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sheet := range sheets {
+		sheetName := timesheetSheetName(sheet.DepartmentName, usedNames)
+		if i == 0 {
+			if err := file.SetSheetName("Sheet1", sheetName); err != nil {
+				return nil, err
+			}
+		} else if _, err := file.NewSheet(sheetName); err != nil {
+			return nil, err
+		}
+
+		file.SetCellValue(sheetName, "A1", fmt.Sprintf("Attendance Timesheet %s - %s", startDate.Format("January 2006"), sheet.DepartmentName))
+		file.SetCellValue(sheetName, "A2", legend)
+
+		header := timesheetHeader(days)
+		if err := file.SetSheetRow(sheetName, "A4", &header); err != nil {
+			return nil, err
+		}
+		lastColumn, _ := excelize.CoordinatesToCellName(len(header), 4)
+		file.SetCellStyle(sheetName, "A4", lastColumn, headerStyle)
+
+		for r, row := range sheet.Rows {
+			values := []interface{}{r + 1, row.EmployeeID, row.Username, row.FullName}
+			for _, code := range row.Codes {
+				values = append(values, code)
+			}
+			values = append(values, row.Present, row.Absent, row.Leave, row.LateMinutes, row.EarlyLeavingMinutes, row.OvertimeMinutes)
+
+			cell, _ := excelize.CoordinatesToCellName(1, r+5)
+			if err := file.SetSheetRow(sheetName, cell, &values); err != nil {
+				return nil, err
+			}
+		}
+
+		file.SetColWidth(sheetName, "D", "D", 28)
+		firstDay, _ := excelize.ColumnNumberToName(5)
+		lastDay, _ := excelize.ColumnNumberToName(4 + days)
+		file.SetColWidth(sheetName, firstDay, lastDay, 4)
+	}
+
+	if len(sheets) == 0 {
+		file.SetCellValue("Sheet1", "A1", "No attendance data")
+	}
+
+	return file.WriteToBuffer()
+}
+
+func writeAttendanceTimesheetCSV(sheets []timesheetSheet, startDate time.Time) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	days := startDate.AddDate(0, 1, -1).Day()
+	header := []string{"Department", "Employee ID", "Username", "Full Name"}
+	for day := 1; day <= days; day++ {
+		header = append(header, startDate.AddDate(0, 0, day-1).Format("2006-01-02"))
+	}
+	header = append(header, "Present", "Absent", "Leave", "Late Minutes", "Early Leaving Minutes", "Overtime Minutes")
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range sheets {
+		for _, row := range sheet.Rows {
+			record := []string{sheet.DepartmentName, strconv.Itoa(int(row.EmployeeID)), row.Username, row.FullName}
+			record = append(record, row.Codes...)
+			record = append(record,
+				strconv.Itoa(row.Present),
+				strconv.Itoa(row.Absent),
+				strconv.Itoa(row.Leave),
+				strconv.Itoa(row.LateMinutes),
+				strconv.Itoa(row.EarlyLeavingMinutes),
+				strconv.Itoa(row.OvertimeMinutes),
+			)
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	return &buf, writer.Error()
+}
+
+// ExportAttendanceTimesheetByAdmin mengekspor timesheet absensi bulanan dalam format XLSX atau CSV
+func ExportAttendanceTimesheetByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		monthYear := c.QueryParam("month_year")
+		if monthYear == "" || len(monthYear) != 7 || monthYear[4] != '-' {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid month_year format. Required format: yyyy-mm"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		startDate, err := time.Parse("2006-01-02", monthYear+"-01")
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid month_year"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var departmentID uint
+		if departmentIDStr := c.QueryParam("department_id"); departmentIDStr != "" {
+			id, err := strconv.Atoi(departmentIDStr)
+			if err != nil || id <= 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid department ID"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var department models.Department
+			if err := db.First(&department, id).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Department not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			departmentID = department.ID
+		}
+
+		format := strings.ToLower(c.QueryParam("format"))
+		if format == "" {
+			format = "xlsx"
+		}
+		if format != "xlsx" && format != "csv" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Format must be xlsx or csv"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		sheets, err := buildAttendanceTimesheet(db, startDate, departmentID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch attendance data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var buf *bytes.Buffer
+		contentType := "text/csv"
+		if format == "xlsx" {
+			buf, err = writeAttendanceTimesheetXLSX(sheets, startDate)
+			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		} else {
+			buf, err = writeAttendanceTimesheetCSV(sheets, startDate)
+		}
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate timesheet"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		filename := fmt.Sprintf("attendance_timesheet_%s.%s", monthYear, format)
+		c.Response().Header().Set("Content-Type", contentType)
+		c.Response().Header().Set("Content-Disposition", "attachment; filename="+filename)
+		c.Response().WriteHeader(http.StatusOK)
+		_, err = c.Response().Write(buf.Bytes())
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to send timesheet"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Punch dari PIN yang sama dalam rentang ini dianggap scan ganda
+const biometricDuplicateWindow = time.Minute
+
+type BiometricPINRequest struct {
+	BiometricPIN string `json:"biometric_pin"`
+}
+
+type biometricUnmatchedPIN struct {
+	PIN     string `json:"pin"`
+	Punches int    `json:"punches"`
+}
+
+type biometricDuplicatePunch struct {
+	PIN       string `json:"pin"`
+	Timestamp string `json:"timestamp"`
+	Line      int    `json:"line"`
+}
+
+type BiometricImportReport struct {
+	TotalPunches     int                          `json:"total_punches"`
+	Created          int                          `json:"created"`
+	Updated          int                          `json:"updated"`
+	Unchanged        int                          `json:"unchanged"`
+	Failed           int                          `json:"failed"`
+	UnmatchedPINs    []biometricUnmatchedPIN      `json:"unmatched_pins"`
+	DuplicatePunches []biometricDuplicatePunch    `json:"duplicate_punches"`
+	ParseErrors      []helper.BiometricParseError `json:"parse_errors"`
+}
+
+// normalizeBiometricPIN menyamakan format PIN dengan hasil parser (tanpa nol di depan)
+func normalizeBiometricPIN(pin string) string {
+	pin = strings.TrimSpace(pin)
+	trimmed := strings.TrimLeft(pin, "0")
+	if trimmed == "" && pin != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// SetEmployeeBiometricPINByAdmin memetakan PIN mesin sidik jari ke karyawan
+func SetEmployeeBiometricPINByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"})
+		}
+
+		var request BiometricPINRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"})
+		}
+
+		pin := normalizeBiometricPIN(request.BiometricPIN)
+		if pin != "" {
+			var count int64
+			db.Model(&models.Employee{}).Where("biometric_pin = ? AND id <> ?", pin, employee.ID).Count(&count)
+			if count > 0 {
+				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Biometric PIN is already used by another employee"})
+			}
+		}
+
+		db.Model(&employee).Update("biometric_pin", pin)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employee biometric PIN updated successfully",
+			"data":    employee,
+		})
+	}
+}
+
+// ImportBiometricAttendanceByAdmin mengimpor log mesin sidik jari. Punch per karyawan per hari dipasangkan
+// menjadi jam masuk (paling awal) dan jam keluar (paling akhir), lalu absensi dibuat atau diperbarui.
+func ImportBiometricAttendanceByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid file"})
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to open file"})
+		}
+		defer src.Close()
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		punches, parseErrors, err := helper.ParseBiometricLog(src, loc)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Failed to read biometric log"})
+		}
+
+		report := BiometricImportReport{
+			TotalPunches:     len(punches),
+			UnmatchedPINs:    []biometricUnmatchedPIN{},
+			DuplicatePunches: []biometricDuplicatePunch{},
+			ParseErrors:      parseErrors,
+		}
+
+		var employees []models.Employee
+		db.Where("biometric_pin <> ?", "").Find(&employees)
+		employeeByPIN := make(map[string]models.Employee)
+		for _, employee := range employees {
+			employeeByPIN[normalizeBiometricPIN(employee.BiometricPIN)] = employee
+		}
+
+		// Kelompokkan punch per PIN per tanggal, abaikan scan ganda
+		type dailyPunches struct {
+			employee models.Employee
+			date     string
+			times    []time.Time
+		}
+		groups := make(map[string]*dailyPunches)
+		unmatched := make(map[string]int)
+		var lastPunch helper.BiometricPunch
+		for i, punch := range punches {
+			if i > 0 && punch.PIN == lastPunch.PIN && punch.Timestamp.Sub(lastPunch.Timestamp) < biometricDuplicateWindow {
+				report.DuplicatePunches = append(report.DuplicatePunches, biometricDuplicatePunch{
+					PIN:       punch.PIN,
+					Timestamp: punch.Timestamp.Format("2006-01-02 15:04:05"),
+					Line:      punch.Line,
+				})
+				continue
+			}
+			lastPunch = punch
+
+			employee, ok := employeeByPIN[punch.PIN]
+			if !ok {
+				unmatched[punch.PIN]++
+				continue
+			}
+
+			date := punch.Timestamp.Format("2006-01-02")
+			key := punch.PIN + "|" + date
+			group, ok := groups[key]
+			if !ok {
+				group = &dailyPunches{employee: employee, date: date}
+				groups[key] = group
+			}
+			group.times = append(group.times, punch.Timestamp)
+		}
+
+		for pin, count := range unmatched {
+			report.UnmatchedPINs = append(report.UnmatchedPINs, biometricUnmatchedPIN{PIN: pin, Punches: count})
+		}
+		sort.Slice(report.UnmatchedPINs, func(i, j int) bool { return report.UnmatchedPINs[i].PIN < report.UnmatchedPINs[j].PIN })
+
+		keys := make([]string, 0, len(groups))
+		for key := range groups {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		adminName := adminUser.FirstName + " " + adminUser.LastName
+		for _, key := range keys {
+			group := groups[key]
+			inTime := group.times[0].Format("15:04:05")
+			outTime := ""
+			if len(group.times) > 1 {
+				outTime = group.times[len(group.times)-1].Format("15:04:05")
+			}
+
+			var attendance models.Attendance
+			isNew := db.Where("employee_id = ? AND attendance_date = ?", group.employee.ID, group.date).First(&attendance).Error != nil
+			if isNew {
+				attendance = models.Attendance{
+					EmployeeID:       group.employee.ID,
+					Username:         group.employee.Username,
+					FullNameEmployee: group.employee.FirstName + " " + group.employee.LastName,
+					AttendanceDate:   group.date,
+				}
+			} else if attendance.Status == "Absent" || attendance.Status == "On Leave" {
+				attendance.InTime = ""
+				attendance.OutTime = ""
+			}
+
+			auditLog := newAttendanceAuditLog(attendance, "BiometricImport", 0)
+
+			// Gabungkan dengan absensi yang sudah tercatat dari aplikasi atau kiosk
+			if attendance.InTime != "" && attendance.InTime < inTime {
+				inTime = attendance.InTime
+			}
+			if attendance.OutTime != "" && attendance.OutTime > outTime {
+				outTime = attendance.OutTime
+			}
+			if outTime == inTime {
+				outTime = ""
+			}
+
+			if !isNew && attendance.InTime == inTime && attendance.OutTime == outTime {
+				report.Unchanged++
+				continue
+			}
+
+			attendance.InTime = inTime
+			attendance.OutTime = outTime
+			if err := recalculateAttendance(db, group.employee, &attendance); err != nil {
+				log.Printf("Failed to recalculate biometric attendance for employee %s on %s: %v\n", group.employee.Username, group.date, err)
+				report.Failed++
+				continue
+			}
+
+			if isNew {
+				currentTime := time.Now()
+				attendance.CreatedAt = &currentTime
+			}
+			if err := db.Save(&attendance).Error; err != nil {
+				report.Failed++
+				continue
+			}
+
+			completeAttendanceAuditLog(&auditLog, attendance, "Admin", adminUser.ID, adminName)
+			db.Create(&auditLog)
+
+			if isNew {
+				report.Created++
+			} else {
+				report.Updated++
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Biometric attendance imported successfully",
+			"data":    report,
+		})
+	}
+}
@@ -0,0 +1,787 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var validRecruitmentStageTypes = map[string]bool{
+	"Screening": true,
+	"Interview": true,
+	"Offer":     true,
+	"Hired":     true,
+	"Rejected":  true,
+}
+
+// defaultRecruitmentStages dibuat otomatis jika pipeline belum diatur
+var defaultRecruitmentStages = []models.RecruitmentStage{
+	{Name: "Screening", StageType: "Screening", SortOrder: 1},
+	{Name: "Interview", StageType: "Interview", SortOrder: 2},
+	{Name: "Offer", StageType: "Offer", SortOrder: 3},
+	{Name: "Hired", StageType: "Hired", SortOrder: 4},
+	{Name: "Rejected", StageType: "Rejected", SortOrder: 5},
+}
+
+// defaultCandidateStageMessages dipakai untuk email kandidat jika tahap tidak memiliki pesan sendiri
+var defaultCandidateStageMessages = map[string]string{
+	"Screening": "Lamaran Anda sedang ditinjau oleh tim rekrutmen kami.",
+	"Interview": "Selamat, Anda lolos ke tahap wawancara. Jadwal wawancara akan kami kirimkan secara terpisah.",
+	"Offer":     "Selamat, Anda telah sampai pada tahap penawaran kerja. Tim kami akan segera mengirimkan surat penawaran.",
+	"Hired":     "Selamat bergabung! Tim HR akan menghubungi Anda untuk proses onboarding.",
+	"Rejected":  "Terima kasih atas minat Anda. Setelah pertimbangan yang matang, kami belum dapat melanjutkan lamaran Anda ke tahap berikutnya.",
+}
+
+type RecruitmentStageRequest struct {
+	Name         string `json:"name"`
+	StageType    string `json:"stage_type"`
+	SortOrder    int    `json:"sort_order"`
+	EmailMessage string `json:"email_message"`
+}
+
+type CandidateTransitionRequest struct {
+	StageID          uint   `json:"stage_id"`
+	Note             string `json:"note"`              // Catatan internal
+	CandidateMessage string `json:"candidate_message"` // Opsional, menggantikan pesan email default tahap
+	NotifyCandidate  *bool  `json:"notify_candidate"`  // Default true
+}
+
+// ensureRecruitmentStages membuat tahapan default jika tabel masih kosong
+func ensureRecruitmentStages(db *gorm.DB) {
+	var count int64
+	db.Model(&models.RecruitmentStage{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	currentTime := time.Now()
+	for _, stage := range defaultRecruitmentStages {
+		stage.CreatedAt = &currentTime
+		db.Create(&stage)
+	}
+}
+
+// initialRecruitmentStage adalah tahap pertama (bukan Hired/Rejected) untuk lamaran baru
+func initialRecruitmentStage(db *gorm.DB) (models.RecruitmentStage, error) {
+	ensureRecruitmentStages(db)
+
+	var stage models.RecruitmentStage
+	err := db.Where("stage_type NOT IN ?", []string{"Hired", "Rejected"}).Order("sort_order ASC").Order("id ASC").First(&stage).Error
+	return stage, err
+}
+
+func candidateStatusForStage(stageType string) string {
+	switch stageType {
+	case "Hired":
+		return "Hired"
+	case "Rejected":
+		return "Rejected"
+	}
+	return "Active"
+}
+
+// transitionCandidate memindahkan kandidat ke tahap lain, mencatat riwayat dan mengirim email ke kandidat
+func transitionCandidate(db *gorm.DB, candidate *models.Candidate, stage models.RecruitmentStage, note, candidateMessage string, notifyCandidate bool, adminUser models.Admin) error {
+	if candidate.StageType == "Hired" {
+		return errors.New("Candidate has already been hired")
+	}
+	if candidate.StageID == stage.ID {
+		return errors.New("Candidate is already in this stage")
+	}
+
+	currentTime := time.Now()
+	history := models.CandidateStageHistory{
+		CandidateID:            candidate.ID,
+		FromStageID:            candidate.StageID,
+		FromStageName:          candidate.StageName,
+		ToStageID:              stage.ID,
+		ToStageName:            stage.Name,
+		Note:                   note,
+		ChangedByAdminID:       adminUser.ID,
+		ChangedByAdminUsername: adminUser.Username,
+		CreatedAt:              &currentTime,
+	}
+
+	candidate.StageID = stage.ID
+	candidate.StageName = stage.Name
+	candidate.StageType = stage.StageType
+	candidate.Status = candidateStatusForStage(stage.StageType)
+	candidate.UpdatedAt = currentTime
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(candidate).Error; err != nil {
+			return err
+		}
+		return tx.Create(&history).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if candidate.Status != "Active" {
+		cancelUpcomingInterviews(db, candidate.ID)
+	}
+
+	if notifyCandidate && candidate.Email != "" {
+		message := candidateMessage
+		if message == "" {
+			message = stage.EmailMessage
+		}
+		if message == "" {
+			message = defaultCandidateStageMessages[stage.StageType]
+		}
+
+		err := helper.SendCandidateStageNotification(candidate.Email, strings.TrimSpace(candidate.FirstName+" "+candidate.LastName), candidate.JobTitle, stage.Name, message)
+		if err != nil {
+			fmt.Println("Failed to send candidate stage notification:", err)
+		}
+	}
+
+	return nil
+}
+
+func GetRecruitmentStagesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		ensureRecruitmentStages(db)
+
+		var stages []models.RecruitmentStage
+		db.Order("sort_order ASC").Order("id ASC").Find(&stages)
+
+		type stageCount struct {
+			StageID uint
+			Count   int
+		}
+		var counts []stageCount
+		db.Model(&models.Candidate{}).Select("stage_id, count(*) as count").Group("stage_id").Scan(&counts)
+
+		candidateCounts := map[uint]int{}
+		for _, count := range counts {
+			candidateCounts[count.StageID] = count.Count
+		}
+
+		var response []map[string]interface{}
+		for _, stage := range stages {
+			response = append(response, map[string]interface{}{
+				"stage":      stage,
+				"candidates": candidateCounts[stage.ID],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Recruitment stages retrieved successfully",
+			"stages":  response,
+		})
+	}
+}
+
+func CreateRecruitmentStageByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request RecruitmentStageRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		request.Name = strings.TrimSpace(request.Name)
+		if len(request.Name) < 3 || len(request.Name) > 50 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Stage name must be between 3 and 50 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !validRecruitmentStageTypes[request.StageType] {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid stage type. Allowed values: Screening, Interview, Offer, Hired, Rejected"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		ensureRecruitmentStages(db)
+
+		if request.SortOrder <= 0 {
+			var maxSortOrder int
+			db.Model(&models.RecruitmentStage{}).Select("COALESCE(MAX(sort_order), 0)").Scan(&maxSortOrder)
+			request.SortOrder = maxSortOrder + 1
+		}
+
+		currentTime := time.Now()
+		stage := models.RecruitmentStage{
+			Name:         request.Name,
+			StageType:    request.StageType,
+			SortOrder:    request.SortOrder,
+			EmailMessage: request.EmailMessage,
+			CreatedAt:    &currentTime,
+		}
+		if err := db.Create(&stage).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create recruitment stage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Recruitment stage created successfully",
+			"stage":   stage,
+		})
+	}
+}
+
+func UpdateRecruitmentStageByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var stage models.RecruitmentStage
+		if err := db.First(&stage, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Recruitment stage not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request RecruitmentStageRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Name = strings.TrimSpace(request.Name); request.Name != "" {
+			if len(request.Name) < 3 || len(request.Name) > 50 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Stage name must be between 3 and 50 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			stage.Name = request.Name
+		}
+
+		if request.StageType != "" && request.StageType != stage.StageType {
+			if !validRecruitmentStageTypes[request.StageType] {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid stage type. Allowed values: Screening, Interview, Offer, Hired, Rejected"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var candidateCount int64
+			db.Model(&models.Candidate{}).Where("stage_id = ?", stage.ID).Count(&candidateCount)
+			if candidateCount > 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Cannot change the type of a stage that still has candidates"}
+				return c.JSON(http.StatusConflict, errorResponse)
+			}
+			stage.StageType = request.StageType
+		}
+
+		if request.SortOrder > 0 {
+			stage.SortOrder = request.SortOrder
+		}
+		if request.EmailMessage != "" {
+			stage.EmailMessage = request.EmailMessage
+		}
+		stage.UpdatedAt = time.Now()
+
+		if err := db.Save(&stage).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update recruitment stage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		// Nama tahap pada kandidat ikut diperbarui
+		db.Model(&models.Candidate{}).Where("stage_id = ?", stage.ID).Updates(map[string]interface{}{"stage_name": stage.Name, "stage_type": stage.StageType})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Recruitment stage updated successfully",
+			"stage":   stage,
+		})
+	}
+}
+
+func DeleteRecruitmentStageByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var stage models.RecruitmentStage
+		if err := db.First(&stage, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Recruitment stage not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var candidateCount int64
+		db.Model(&models.Candidate{}).Where("stage_id = ?", stage.ID).Count(&candidateCount)
+		if candidateCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: fmt.Sprintf("Stage still has %d candidates", candidateCount)}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		db.Delete(&stage)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Recruitment stage deleted successfully",
+		})
+	}
+}
+
+func GetAllCandidatesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.Candidate{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR job_title ILIKE ?", searchPattern, searchPattern, searchPattern, searchPattern)
+		}
+		if jobID := c.QueryParam("job_id"); jobID != "" {
+			query = query.Where("new_job_id = ?", jobID)
+		}
+		if stageID := c.QueryParam("stage_id"); stageID != "" {
+			query = query.Where("stage_id = ?", stageID)
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var candidates []models.Candidate
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&candidates).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching candidates"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Candidates retrieved successfully",
+			"candidates": candidates,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetCandidateByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var history []models.CandidateStageHistory
+		db.Where("candidate_id = ?", candidate.ID).Order("id ASC").Find(&history)
+
+		var interviews []models.Interview
+		db.Preload("Interviewers").Where("candidate_id = ?", candidate.ID).Order("scheduled_at ASC").Find(&interviews)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":          http.StatusOK,
+			"error":         false,
+			"message":       "Candidate retrieved successfully",
+			"candidate":     candidate,
+			"stage_history": history,
+			"interviews":    interviews,
+			"feedback":      candidateFeedbackSummary(db, candidate.ID),
+		})
+	}
+}
+
+// TransitionCandidateStageByAdmin memindahkan kandidat ke tahap pipeline lain dengan catatan
+func TransitionCandidateStageByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request CandidateTransitionRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var stage models.RecruitmentStage
+		if err := db.First(&stage, request.StageID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Recruitment stage not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Tahap Hired hanya dapat dicapai melalui proses hire agar data karyawan dan onboarding ikut dibuat
+		if stage.StageType == "Hired" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Candidates can only be moved to a Hired stage through /candidates/:id/hire"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(request.Note) > 2000 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Note must not exceed 2000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		notifyCandidate := request.NotifyCandidate == nil || *request.NotifyCandidate
+		if err := transitionCandidate(db, &candidate, stage, request.Note, request.CandidateMessage, notifyCandidate, adminUser); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Candidate moved to " + stage.Name,
+			"candidate": candidate,
+		})
+	}
+}
+
+func DownloadCandidateCVByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		reader, err := fileStorage.Open(candidate.CVKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "CV file not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+		defer reader.Close()
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", candidate.CVFileName))
+		return c.Stream(http.StatusOK, candidate.CVContentType, reader)
+	}
+}
+
+func DeleteCandidateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if fileStorage, err := helper.NewFileStorage(); err == nil && candidate.CVKey != "" {
+			if err := fileStorage.Delete(candidate.CVKey); err != nil {
+				fmt.Println("Failed to delete candidate CV:", err)
+			}
+		}
+
+		deleteCandidateInterviews(db, candidate.ID)
+		deleteCandidateOfferLetters(db, candidate.ID)
+		db.Where("candidate_id = ?", candidate.ID).Delete(&models.CandidateStageHistory{})
+		db.Delete(&candidate)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Candidate deleted successfully",
+		})
+	}
+}
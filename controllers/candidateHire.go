@@ -0,0 +1,351 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Pembagi upah per jam untuk karyawan bulanan (Kepmenakertrans 102/2004)
+const monthlyHoursDivisor = 173
+
+type HireCandidateRequest struct {
+	Username      string  `json:"username"` // Opsional, dibuat dari email kandidat jika kosong
+	Password      string  `json:"password"` // Opsional, dibuat acak jika kosong
+	Gender        string  `json:"gender"`
+	BirthdayDate  string  `json:"birthday_date"`
+	ShiftID       uint    `json:"shift_id"`
+	RoleID        uint    `json:"role_id"`
+	DepartmentID  uint    `json:"department_id"`  // Default: departemen dari jabatan lowongan
+	DesignationID uint    `json:"designation_id"` // Default: jabatan lowongan
+	BasicSalary   float64 `json:"basic_salary"`   // Default: gaji yang ditawarkan
+	HourlyRate    float64 `json:"hourly_rate"`    // Default: gaji pokok / 173
+	PaySlipType   string  `json:"pay_slip_type"`
+	JoinDate      string  `json:"join_date"`  // Format: yyyy-mm-dd
+	ManagerID     uint    `json:"manager_id"` // Default: kepala departemen
+	TemplateID    uint    `json:"template_id"`
+}
+
+var usernameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// candidateUsername membuat username unik 6-15 karakter dari alamat email kandidat
+func candidateUsername(db *gorm.DB, email string) string {
+	base := usernameSanitizer.ReplaceAllString(strings.Split(email, "@")[0], "")
+	if len(base) > 12 {
+		base = base[:12]
+	}
+	for len(base) < 6 {
+		base += "0"
+	}
+
+	username := base
+	for i := 1; ; i++ {
+		var count int64
+		db.Model(&models.Employee{}).Where("username = ?", username).Count(&count)
+		if count == 0 {
+			return username
+		}
+		username = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// HireCandidateByAdmin mengubah kandidat pada tahap Offer menjadi karyawan dan membuat checklist onboarding
+func HireCandidateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if candidate.EmployeeID != 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Candidate has already been hired"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		if candidate.Status != "Active" || candidate.StageType != "Offer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only candidates in an offer stage can be hired"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Jika surat penawaran sudah diterbitkan, kandidat harus sudah menerimanya
+		var offerLetterCount, acceptedOfferCount int64
+		db.Model(&models.OfferLetter{}).Where("candidate_id = ? AND letter_type = ?", candidate.ID, "Offer Letter").Count(&offerLetterCount)
+		db.Model(&models.OfferLetter{}).Where("candidate_id = ? AND letter_type = ? AND status = ?", candidate.ID, "Offer Letter", "Accepted").Count(&acceptedOfferCount)
+		if offerLetterCount > 0 && acceptedOfferCount == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Candidate has not accepted the offer letter"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request HireCandidateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Gender == "" || request.ShiftID == 0 || request.RoleID == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Gender, shift and role are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString(candidate.FirstName) || len(candidate.FirstName) > 30 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Candidate first name must be at most 30 characters and contain only letters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !regexp.MustCompile(`^\d{10,14}$`).MatchString(candidate.ContactNumber) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Candidate contact number must be between 10 and 14 digits and contain only numbers"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var job models.NewJob
+		db.First(&job, candidate.NewJobID)
+
+		if request.DesignationID == 0 {
+			request.DesignationID = job.DesignationID
+		}
+		var designation models.Designation
+		if err := db.First(&designation, request.DesignationID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid designation ID. Designation not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.DepartmentID == 0 {
+			request.DepartmentID = designation.DepartmentID
+		}
+		var department models.Department
+		if err := db.First(&department, request.DepartmentID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid department ID. Department not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var shift models.Shift
+		if err := db.First(&shift, request.ShiftID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid shift ID. Shift not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var role models.Role
+		if err := db.First(&role, request.RoleID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid role ID. Role not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.BasicSalary == 0 {
+			request.BasicSalary = candidate.OfferedSalary
+		}
+		if request.BasicSalary <= 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Basic salary is required when the candidate has no offered salary"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if request.HourlyRate <= 0 {
+			request.HourlyRate = request.BasicSalary / monthlyHoursDivisor
+		}
+		if request.PaySlipType == "" {
+			request.PaySlipType = "Monthly"
+		}
+
+		if request.JoinDate == "" {
+			request.JoinDate = candidate.JoinDate
+		}
+		if request.JoinDate == "" {
+			request.JoinDate = time.Now().Format("2006-01-02")
+		}
+		joinDate, err := time.Parse("2006-01-02", request.JoinDate)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid join date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Username == "" {
+			request.Username = candidateUsername(db, candidate.Email)
+		}
+		if len(request.Username) < 6 || len(request.Username) > 15 || !regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString(request.Username) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username must be between 6 and 15 characters and contain only letters and numbers"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if request.Password == "" {
+			request.Password = helper.GenerateUniqueToken()[:12]
+		}
+
+		var existingEmployee models.Employee
+		result = db.Where("username = ? OR email = ? OR contact_number = ?", request.Username, candidate.Email, candidate.ContactNumber).First(&existingEmployee)
+		if result.Error == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "An employee with the same username, email or contact number already exists"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check existing employees"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var hiredStage models.RecruitmentStage
+		if err := db.Where("stage_type = ?", "Hired").Order("sort_order ASC").First(&hiredStage).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Recruitment pipeline has no hired stage"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		template, err := onboardingTemplateByID(db, request.TemplateID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Onboarding template not found"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var manager *models.Employee
+		managerID := request.ManagerID
+		if managerID == 0 {
+			managerID = department.EmployeeID
+		}
+		if managerID != 0 {
+			var managerEmployee models.Employee
+			if err := db.Where("id = ? AND is_exit = ?", managerID, false).First(&managerEmployee).Error; err == nil {
+				manager = &managerEmployee
+			} else if request.ManagerID != 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Manager must be an active employee"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		var itEmployee *models.Employee
+		if employee, ok := itOnboardingAssignee(db); ok {
+			itEmployee = &employee
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to hash password"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		currentTime := time.Now()
+		// CreatedAt dipakai proses absensi sebagai tanggal mulai kerja, sehingga diisi awal hari join date (Asia/Jakarta)
+		startDate := joinDate
+		if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+			startDate = time.Date(joinDate.Year(), joinDate.Month(), joinDate.Day(), 0, 0, 0, 0, loc)
+		}
+		employee := models.Employee{
+			PayrollID:     generateUniquePayrollID(),
+			FirstName:     candidate.FirstName,
+			LastName:      candidate.LastName,
+			FullName:      strings.TrimSpace(candidate.FirstName + " " + candidate.LastName),
+			ContactNumber: candidate.ContactNumber,
+			Gender:        request.Gender,
+			BirthdayDate:  request.BirthdayDate,
+			Email:         candidate.Email,
+			Username:      request.Username,
+			Password:      string(hashedPassword),
+			ShiftID:       shift.ID,
+			Shift:         shift.ShiftName,
+			RoleID:        role.ID,
+			Role:          role.RoleName,
+			DepartmentID:  department.ID,
+			Department:    department.DepartmentName,
+			DesignationID: designation.ID,
+			Designation:   designation.DesignationName,
+			BasicSalary:   request.BasicSalary,
+			HourlyRate:    request.HourlyRate,
+			PaySlipType:   request.PaySlipType,
+			CreatedAt:     &startDate,
+		}
+
+		var checklist models.OnboardingChecklist
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&employee).Error; err != nil {
+				return err
+			}
+
+			var err error
+			checklist, err = createOnboardingChecklist(tx, employee, candidate.ID, template, manager, itEmployee, joinDate)
+			if err != nil {
+				return err
+			}
+
+			return tx.Model(&models.Candidate{}).Where("id = ?", candidate.ID).Updates(map[string]interface{}{
+				"employee_id":    employee.ID,
+				"offered_salary": request.BasicSalary,
+				"join_date":      request.JoinDate,
+				"hired_at":       currentTime,
+			}).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to hire candidate"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		candidate.EmployeeID = employee.ID
+		candidate.OfferedSalary = request.BasicSalary
+		candidate.JoinDate = request.JoinDate
+		candidate.HiredAt = &currentTime
+		if err := transitionCandidate(db, &candidate, hiredStage, "Hired as employee "+employee.Username, "", true, adminUser); err != nil {
+			fmt.Println("Failed to move candidate to hired stage:", err)
+		}
+
+		if err := helper.SendEmployeeAccountNotificationWithPlainTextPassword(employee.Email, employee.FullName, employee.Username, request.Password); err != nil {
+			fmt.Println("Failed to send welcome email:", err)
+		}
+		notifyOnboardingAssignees(db, checklist)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Candidate hired successfully",
+			"employee": EmployeeResponse{
+				ID:            employee.ID,
+				FirstName:     employee.FirstName,
+				LastName:      employee.LastName,
+				FullName:      employee.FullName,
+				ContactNumber: employee.ContactNumber,
+				Email:         employee.Email,
+				Gender:        employee.Gender,
+				Shift:         employee.Shift,
+				Role:          employee.Role,
+				Department:    employee.Department,
+				Designation:   employee.Designation,
+				HourlyRate:    employee.HourlyRate,
+				PaySlipType:   employee.PaySlipType,
+			},
+			"candidate": candidate,
+			"checklist": checklist,
+		})
+	}
+}
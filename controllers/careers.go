@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/models"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxCandidateCVSize = 5 * 1024 * 1024
+
+var candidateEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// publishedJobs membatasi lowongan yang tampil di halaman karir: sudah dipublikasikan dan belum ditutup
+func publishedJobs(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.NewJob{}).Where("is_publish = ? AND date_closing >= ?", true, time.Now().Format("2006-01-02"))
+}
+
+// isAllowedCVFile menerima CV berformat PDF atau Word
+func isAllowedCVFile(contentType, fileName string) bool {
+	if contentType == "application/pdf" {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".doc":
+		return contentType == "application/msword" || contentType == "application/octet-stream"
+	case ".docx":
+		return contentType == "application/zip" || strings.Contains(contentType, "wordprocessingml")
+	}
+	return false
+}
+
+// GetPublishedJobs adalah endpoint publik halaman karir
+func GetPublishedJobs(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := publishedJobs(db)
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("title ILIKE ? OR designation_name ILIKE ? OR job_type ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+		if jobType := c.QueryParam("job_type"); jobType != "" {
+			query = query.Where("job_type = ?", jobType)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var newJobs []models.NewJob
+		query.Order("date_closing ASC").Order("id DESC").Offset(offset).Limit(perPage).Find(&newJobs)
+
+		newJobResponses := []NewJobResponse{}
+		for _, job := range newJobs {
+			newJobResponses = append(newJobResponses, NewJobResponse{
+				ID:                job.ID,
+				Title:             job.Title,
+				JobType:           job.JobType,
+				DesignationID:     job.DesignationID,
+				DesignationName:   job.DesignationName,
+				NumberOfPosition:  job.NumberOfPosition,
+				IsPublish:         job.IsPublish,
+				DateClosing:       job.DateClosing,
+				MinimumExperience: job.MinimumExperience,
+				ShortDescription:  job.ShortDescription,
+				LongDescription:   job.LongDescription,
+				CreatedAt:         job.CreatedAt,
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Published jobs retrieved successfully",
+			"new_jobs": newJobResponses,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetPublishedJobByID(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var job models.NewJob
+		if err := publishedJobs(db).Where("id = ?", c.Param("id")).First(&job).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Job not found or no longer accepting applications"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Published job retrieved successfully",
+			"new_job": NewJobResponse{
+				ID:                job.ID,
+				Title:             job.Title,
+				JobType:           job.JobType,
+				DesignationID:     job.DesignationID,
+				DesignationName:   job.DesignationName,
+				NumberOfPosition:  job.NumberOfPosition,
+				IsPublish:         job.IsPublish,
+				DateClosing:       job.DateClosing,
+				MinimumExperience: job.MinimumExperience,
+				ShortDescription:  job.ShortDescription,
+				LongDescription:   job.LongDescription,
+				CreatedAt:         job.CreatedAt,
+			},
+		})
+	}
+}
+
+// ApplyForJob menerima lamaran dari halaman karir (multipart form dengan file "cv")
+func ApplyForJob(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var job models.NewJob
+		if err := publishedJobs(db).Where("id = ?", c.Param("id")).First(&job).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Job not found or no longer accepting applications"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		firstName := strings.TrimSpace(c.FormValue("first_name"))
+		lastName := strings.TrimSpace(c.FormValue("last_name"))
+		email := strings.ToLower(strings.TrimSpace(c.FormValue("email")))
+		contactNumber := strings.TrimSpace(c.FormValue("contact_number"))
+		coverLetter := strings.TrimSpace(c.FormValue("cover_letter"))
+
+		if firstName == "" || email == "" || contactNumber == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "First name, email and contact number are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !candidateEmailPattern.MatchString(email) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid email format"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(coverLetter) > 5000 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cover letter must not exceed 5000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var existingCandidate models.Candidate
+		if err := db.Where("new_job_id = ? AND email = ?", job.ID, email).First(&existingCandidate).Error; err == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "You have already applied for this job"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		file, err := c.FormFile("cv")
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "CV file is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if helper.IsFileSizeExceeds(file, maxCandidateCVSize) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "CV must not exceed 5MB"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to open CV file"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		defer src.Close()
+
+		fileData, err := io.ReadAll(src)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to read CV file"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		contentType := helper.DetectContentType(fileData, file.Filename)
+		if !isAllowedCVFile(contentType, file.Filename) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "CV must be a PDF or Word document"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		stage, err := initialRecruitmentStage(db)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Recruitment pipeline is not configured"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		cvKey := fmt.Sprintf("candidates/%d/%d%s", job.ID, time.Now().UnixNano(), strings.ToLower(filepath.Ext(file.Filename)))
+		if err := fileStorage.Upload(cvKey, fileData, contentType); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to upload CV"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		source := strings.TrimSpace(c.FormValue("source"))
+		if source == "" {
+			source = "Careers Page"
+		}
+
+		currentTime := time.Now()
+		candidate := models.Candidate{
+			NewJobID:      job.ID,
+			JobTitle:      job.Title,
+			FirstName:     firstName,
+			LastName:      lastName,
+			Email:         email,
+			ContactNumber: contactNumber,
+			CoverLetter:   coverLetter,
+			Source:        source,
+			CVFileName:    file.Filename,
+			CVKey:         cvKey,
+			CVContentType: contentType,
+			StageID:       stage.ID,
+			StageName:     stage.Name,
+			StageType:     stage.StageType,
+			Status:        "Active",
+			AppliedAt:     &currentTime,
+		}
+
+		if err := db.Create(&candidate).Error; err != nil {
+			fileStorage.Delete(cvKey)
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to submit application"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		history := models.CandidateStageHistory{
+			CandidateID: candidate.ID,
+			ToStageID:   stage.ID,
+			ToStageName: stage.Name,
+			Note:        "Application submitted via " + source,
+			CreatedAt:   &currentTime,
+		}
+		db.Create(&history)
+
+		if err := helper.SendCandidateApplicationReceived(candidate.Email, strings.TrimSpace(candidate.FirstName+" "+candidate.LastName), job.Title); err != nil {
+			fmt.Println("Failed to send application confirmation:", err)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Application submitted successfully",
+			"application": map[string]interface{}{
+				"id":         candidate.ID,
+				"job_title":  candidate.JobTitle,
+				"applied_at": candidate.AppliedAt,
+			},
+		})
+	}
+}
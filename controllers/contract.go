@@ -0,0 +1,852 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var validContractTypes = map[string]bool{
+	"PKWT":      true,
+	"PKWTT":     true,
+	"Probation": true,
+	"Intern":    true,
+}
+
+// Pengingat dikirim saat kontrak atau dokumen tersisa 30, 14 dan 7 hari sebelum berakhir
+var expiryReminderDays = []int{30, 14, 7}
+
+// RenewContractRequest adalah body untuk perpanjangan kontrak
+type RenewContractRequest struct {
+	ContractType   string `json:"contract_type"`
+	ContractNumber string `json:"contract_number"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	Notes          string `json:"notes"`
+}
+
+// validateContractPeriod memeriksa jenis kontrak dan tanggal mulai/berakhir, mengembalikan pesan error jika tidak valid
+func validateContractPeriod(contractType, startDate, endDate string) string {
+	if !validContractTypes[contractType] {
+		return "Contract type must be one of PKWT, PKWTT, Probation or Intern"
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return "Invalid start date format. Required format: yyyy-mm-dd"
+	}
+
+	// PKWTT (karyawan tetap) tidak memiliki tanggal berakhir
+	if contractType == "PKWTT" {
+		if endDate != "" {
+			return "PKWTT contracts must not have an end date"
+		}
+		return ""
+	}
+
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return "End date is required for this contract type. Required format: yyyy-mm-dd"
+	}
+	if !end.After(start) {
+		return "End date must be after start date"
+	}
+
+	// Masa percobaan paling lama 3 bulan
+	if contractType == "Probation" && end.After(start.AddDate(0, 3, 0)) {
+		return "Probation period must not exceed 3 months"
+	}
+
+	return ""
+}
+
+// contractDocumentURL membuat URL download sementara (1 jam) untuk dokumen kontrak
+func contractDocumentURL(contract models.EmploymentContract) string {
+	if contract.DocumentKey == "" {
+		return ""
+	}
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		return ""
+	}
+	documentURL, err := fileStorage.SignedURL(contract.DocumentKey, time.Hour)
+	if err != nil {
+		fmt.Println("Failed to create contract document URL:", err)
+		return ""
+	}
+	return documentURL
+}
+
+// SendContractExpiryReminders mengirim pengingat ke HR dan manager 30, 14 dan 7 hari sebelum kontrak berakhir,
+// serta menandai kontrak yang sudah lewat tanggal berakhirnya sebagai Expired (dijalankan harian)
+func SendContractExpiryReminders(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		fmt.Println("Failed to load timezone:", err)
+		return
+	}
+	today := time.Now().In(loc)
+	todayStr := today.Format("2006-01-02")
+
+	db.Model(&models.EmploymentContract{}).
+		Where("status = ? AND end_date <> ? AND end_date < ?", "Active", "", todayStr).
+		Update("status", "Expired")
+
+	var admins []models.Admin
+	db.Where("is_admin_hr = ?", true).Find(&admins)
+
+	sentCount := 0
+	for _, days := range expiryReminderDays {
+		targetDate := today.AddDate(0, 0, days).Format("2006-01-02")
+
+		var contracts []models.EmploymentContract
+		db.Where("status = ? AND end_date = ? AND (last_reminder_days = 0 OR last_reminder_days > ?)", "Active", targetDate, days).Find(&contracts)
+
+		for _, contract := range contracts {
+			for _, admin := range admins {
+				if err := helper.SendContractExpiryReminder(admin.Email, admin.FirstName+" "+admin.LastName, contract.FullNameEmployee, contract.ContractType, contract.EndDate, days); err != nil {
+					fmt.Println("Failed to send contract expiry reminder:", err)
+				}
+			}
+
+			var employee models.Employee
+			if db.First(&employee, contract.EmployeeID).Error == nil {
+				var department models.Department
+				if db.First(&department, employee.DepartmentID).Error == nil && department.EmployeeID != 0 && department.EmployeeID != employee.ID {
+					var manager models.Employee
+					if db.First(&manager, department.EmployeeID).Error == nil {
+						if err := helper.SendContractExpiryReminder(manager.Email, manager.FirstName+" "+manager.LastName, contract.FullNameEmployee, contract.ContractType, contract.EndDate, days); err != nil {
+							fmt.Println("Failed to send contract expiry reminder:", err)
+						}
+					}
+				}
+			}
+
+			db.Model(&contract).Update("last_reminder_days", days)
+			sentCount++
+		}
+	}
+
+	log.Printf("Contract reminder job finished: %d reminders sent\n", sentCount)
+}
+
+func CreateContractByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		if err := c.Bind(&contract); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", contract.EmployeeID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid employee ID. Employee not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if message := validateContractPeriod(contract.ContractType, contract.StartDate, contract.EndDate); message != "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: message}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Satu karyawan hanya boleh memiliki satu kontrak aktif, gunakan renew untuk perpanjangan
+		var activeCount int64
+		db.Model(&models.EmploymentContract{}).Where("employee_id = ? AND status = ?", employee.ID, "Active").Count(&activeCount)
+		if activeCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Employee already has an active contract. Use renew to extend it."}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		contract.FullNameEmployee = employee.FirstName + " " + employee.LastName
+		contract.DepartmentID = employee.DepartmentID
+		contract.Status = "Active"
+		contract.RenewalCount = 0
+		contract.PreviousContractID = 0
+		contract.DocumentKey = ""
+		contract.DocumentName = ""
+		contract.LastReminderDays = 0
+		contract.CreatedByAdminID = adminUser.ID
+		contract.CreatedAt = &currentTime
+
+		db.Create(&contract)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Contract created successfully",
+			"data":    contract,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+func GetAllContractsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.EmploymentContract{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("full_name_employee ILIKE ? OR contract_number ILIKE ?", searchPattern, searchPattern)
+		}
+		if employeeID := c.QueryParam("employee_id"); employeeID != "" {
+			query = query.Where("employee_id = ?", employeeID)
+		}
+		if contractType := c.QueryParam("contract_type"); contractType != "" {
+			query = query.Where("contract_type = ?", contractType)
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var contracts []models.EmploymentContract
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&contracts).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching contracts"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Contracts retrieved successfully",
+			"data":    contracts,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func GetContractByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		result = db.First(&contract, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Contract not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Sertakan riwayat kontrak sebelumnya untuk melihat rantai perpanjangan
+		var previousContracts []models.EmploymentContract
+		previousID := contract.PreviousContractID
+		for previousID != 0 {
+			var previous models.EmploymentContract
+			if db.First(&previous, previousID).Error != nil {
+				break
+			}
+			previousContracts = append(previousContracts, previous)
+			previousID = previous.PreviousContractID
+		}
+
+		successResponse := map[string]interface{}{
+			"code":               http.StatusOK,
+			"error":              false,
+			"message":            "Contract retrieved successfully",
+			"data":               contract,
+			"document_url":       contractDocumentURL(contract),
+			"previous_contracts": previousContracts,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func UpdateContractByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		result = db.First(&contract, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Contract not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var updatedContract models.EmploymentContract
+		if err := c.Bind(&updatedContract); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if updatedContract.ContractNumber != "" {
+			contract.ContractNumber = updatedContract.ContractNumber
+		}
+		if updatedContract.ContractType != "" {
+			contract.ContractType = updatedContract.ContractType
+			if contract.ContractType == "PKWTT" {
+				contract.EndDate = ""
+			}
+		}
+		if updatedContract.StartDate != "" {
+			contract.StartDate = updatedContract.StartDate
+		}
+		if updatedContract.EndDate != "" {
+			if updatedContract.EndDate != contract.EndDate {
+				// Tanggal berakhir berubah, pengingat dikirim ulang dari awal
+				contract.LastReminderDays = 0
+			}
+			contract.EndDate = updatedContract.EndDate
+		}
+		if updatedContract.Notes != "" {
+			contract.Notes = updatedContract.Notes
+		}
+		if updatedContract.Status != "" {
+			if updatedContract.Status != "Active" && updatedContract.Status != "Expired" && updatedContract.Status != "Terminated" {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Status must be one of Active, Expired or Terminated"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			contract.Status = updatedContract.Status
+		}
+
+		if message := validateContractPeriod(contract.ContractType, contract.StartDate, contract.EndDate); message != "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: message}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		db.Save(&contract)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Contract updated successfully",
+			"data":    contract,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func DeleteContractByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		result = db.First(&contract, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Contract not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if contract.DocumentKey != "" {
+			if fileStorage, err := helper.NewFileStorage(); err == nil {
+				if err := fileStorage.Delete(contract.DocumentKey); err != nil {
+					fmt.Println("Failed to delete contract document:", err)
+				}
+			}
+		}
+
+		db.Delete(&contract)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Contract deleted successfully",
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+// UploadContractDocumentByAdmin mengunggah dokumen kontrak (PDF atau gambar hasil scan)
+func UploadContractDocumentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		result = db.First(&contract, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Contract not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		file, err := c.FormFile("document")
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Document file is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if helper.IsFileSizeExceeds(file, 5*1024*1024) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Contract document must not exceed 5MB"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to open document file"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		defer src.Close()
+
+		fileData, err := io.ReadAll(src)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to read document file"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		// Content type ditentukan dari isi file, bukan dari header yang dikirim client
+		contentType := helper.DetectContentType(fileData, file.Filename)
+		if contentType != "application/pdf" && !strings.HasPrefix(contentType, "image/") {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Contract document must be a PDF or image file"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		objectName := fmt.Sprintf("contracts/%d/%d%s", contract.ID, time.Now().Unix(), filepath.Ext(file.Filename))
+		if err := fileStorage.Upload(objectName, fileData, contentType); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to upload contract document"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		// Dokumen lama dihapus setelah dokumen baru berhasil diunggah
+		if contract.DocumentKey != "" {
+			if err := fileStorage.Delete(contract.DocumentKey); err != nil {
+				fmt.Println("Failed to delete previous contract document:", err)
+			}
+		}
+
+		contract.DocumentKey = objectName
+		contract.DocumentName = file.Filename
+		db.Save(&contract)
+
+		successResponse := map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Contract document uploaded successfully",
+			"data":         contract,
+			"document_url": contractDocumentURL(contract),
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+// RenewContractByAdmin memperpanjang kontrak: kontrak lama berstatus Renewed dan kontrak baru dibuat
+// dengan jumlah perpanjangan bertambah satu
+func RenewContractByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var contract models.EmploymentContract
+		result = db.First(&contract, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Contract not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if contract.Status != "Active" && contract.Status != "Expired" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only active or expired contracts can be renewed"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request RenewContractRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.ContractType == "" {
+			request.ContractType = contract.ContractType
+		}
+
+		// Secara default kontrak baru dimulai sehari setelah kontrak lama berakhir
+		if request.StartDate == "" && contract.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", contract.EndDate)
+			if err == nil {
+				request.StartDate = endDate.AddDate(0, 0, 1).Format("2006-01-02")
+			}
+		}
+
+		if message := validateContractPeriod(request.ContractType, request.StartDate, request.EndDate); message != "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: message}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var employee models.Employee
+		if err := db.First(&employee, contract.EmployeeID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		currentTime := time.Now()
+		renewedContract := models.EmploymentContract{
+			EmployeeID:         contract.EmployeeID,
+			FullNameEmployee:   employee.FirstName + " " + employee.LastName,
+			DepartmentID:       employee.DepartmentID,
+			ContractNumber:     request.ContractNumber,
+			ContractType:       request.ContractType,
+			StartDate:          request.StartDate,
+			EndDate:            request.EndDate,
+			RenewalCount:       contract.RenewalCount + 1,
+			PreviousContractID: contract.ID,
+			Status:             "Active",
+			Notes:              request.Notes,
+			CreatedByAdminID:   adminUser.ID,
+			CreatedAt:          &currentTime,
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&contract).Update("status", "Renewed").Error; err != nil {
+				return err
+			}
+			return tx.Create(&renewedContract).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to renew contract"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Contract renewed successfully",
+			"data":    renewedContract,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+// GetUpcomingContractExpiriesByAdmin menampilkan kontrak aktif yang akan berakhir dalam N hari ke depan (default 30)
+func GetUpcomingContractExpiriesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		days, err := strconv.Atoi(c.QueryParam("days"))
+		if err != nil || days <= 0 {
+			days = 30
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		today := time.Now().In(loc)
+		todayDate, _ := time.Parse("2006-01-02", today.Format("2006-01-02"))
+
+		query := db.Model(&models.EmploymentContract{}).
+			Where("status = ? AND end_date <> ? AND end_date BETWEEN ? AND ?", "Active", "", today.Format("2006-01-02"), today.AddDate(0, 0, days).Format("2006-01-02"))
+		if contractType := c.QueryParam("contract_type"); contractType != "" {
+			query = query.Where("contract_type = ?", contractType)
+		}
+		if departmentID := c.QueryParam("department_id"); departmentID != "" {
+			query = query.Where("department_id = ?", departmentID)
+		}
+
+		var contracts []models.EmploymentContract
+		if err := query.Order("end_date ASC").Find(&contracts).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching contracts"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var report []map[string]interface{}
+		for _, contract := range contracts {
+			endDate, _ := time.Parse("2006-01-02", contract.EndDate)
+
+			departmentName := ""
+			managerName := ""
+			var department models.Department
+			if db.First(&department, contract.DepartmentID).Error == nil {
+				departmentName = department.DepartmentName
+				managerName = department.FullName
+			}
+
+			report = append(report, map[string]interface{}{
+				"contract_id":        contract.ID,
+				"employee_id":        contract.EmployeeID,
+				"full_name_employee": contract.FullNameEmployee,
+				"department":         departmentName,
+				"manager":            managerName,
+				"contract_type":      contract.ContractType,
+				"contract_number":    contract.ContractNumber,
+				"start_date":         contract.StartDate,
+				"end_date":           contract.EndDate,
+				"renewal_count":      contract.RenewalCount,
+				"days_remaining":     int(endDate.Sub(todayDate).Hours() / 24),
+			})
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Upcoming contract expiries retrieved successfully",
+			"days":    days,
+			"data":    report,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
@@ -0,0 +1,690 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ukuran maksimal dokumen karyawan (10MB)
+const maxEmployeeDocumentSize = 10 * 1024 * 1024
+
+// storeEmployeeDocument membaca form multipart (file, document_type_id, title, document_number, issue_date, expiry_date),
+// mengunggah file ke storage dan menyimpan datanya. Mengembalikan status HTTP dan pesan jika gagal.
+func storeEmployeeDocument(c echo.Context, db *gorm.DB, employee models.Employee, uploadedByRole string, uploadedByID uint) (models.EmployeeDocument, int, string) {
+	var document models.EmployeeDocument
+
+	documentTypeID, err := strconv.Atoi(c.FormValue("document_type_id"))
+	if err != nil {
+		return document, http.StatusBadRequest, "Invalid document type ID"
+	}
+
+	var documentType models.DocumentType
+	if err := db.First(&documentType, documentTypeID).Error; err != nil {
+		return document, http.StatusBadRequest, "Invalid document type ID. Document type not found."
+	}
+
+	issueDate := c.FormValue("issue_date")
+	if issueDate != "" {
+		if _, err := time.Parse("2006-01-02", issueDate); err != nil {
+			return document, http.StatusBadRequest, "Invalid issue date format. Required format: yyyy-mm-dd"
+		}
+	}
+
+	expiryDate := c.FormValue("expiry_date")
+	if expiryDate != "" {
+		if _, err := time.Parse("2006-01-02", expiryDate); err != nil {
+			return document, http.StatusBadRequest, "Invalid expiry date format. Required format: yyyy-mm-dd"
+		}
+	} else if documentType.HasExpiry {
+		return document, http.StatusBadRequest, "Expiry date is required for " + documentType.Name
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return document, http.StatusBadRequest, "Document file is required"
+	}
+
+	if helper.IsFileSizeExceeds(file, maxEmployeeDocumentSize) {
+		return document, http.StatusBadRequest, "Document must not exceed 10MB"
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return document, http.StatusInternalServerError, "Failed to open document file"
+	}
+	defer src.Close()
+
+	fileData, err := io.ReadAll(src)
+	if err != nil {
+		return document, http.StatusInternalServerError, "Failed to read document file"
+	}
+
+	// Content type ditentukan dari isi file, bukan dari header yang dikirim client
+	contentType := helper.DetectContentType(fileData, file.Filename)
+	if contentType != "application/pdf" && !strings.HasPrefix(contentType, "image/") {
+		return document, http.StatusBadRequest, "Document must be a PDF or image file"
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		return document, http.StatusInternalServerError, "Failed to initialize file storage"
+	}
+
+	storageKey := fmt.Sprintf("employee_documents/%d/%d%s", employee.ID, time.Now().UnixNano(), filepath.Ext(file.Filename))
+	if err := fileStorage.Upload(storageKey, fileData, contentType); err != nil {
+		return document, http.StatusInternalServerError, "Failed to upload document"
+	}
+
+	title := c.FormValue("title")
+	if title == "" {
+		title = documentType.Name
+	}
+
+	currentTime := time.Now()
+	document = models.EmployeeDocument{
+		EmployeeID:       employee.ID,
+		FullNameEmployee: employee.FirstName + " " + employee.LastName,
+		DocumentTypeID:   documentType.ID,
+		DocumentType:     documentType.Name,
+		Title:            title,
+		DocumentNumber:   c.FormValue("document_number"),
+		IssueDate:        issueDate,
+		ExpiryDate:       expiryDate,
+		FileName:         file.Filename,
+		StorageKey:       storageKey,
+		ContentType:      contentType,
+		FileSize:         file.Size,
+		UploadedByRole:   uploadedByRole,
+		UploadedByID:     uploadedByID,
+		CreatedAt:        &currentTime,
+	}
+
+	if err := db.Create(&document).Error; err != nil {
+		fileStorage.Delete(storageKey)
+		return document, http.StatusInternalServerError, "Failed to save document"
+	}
+
+	return document, http.StatusCreated, ""
+}
+
+// streamEmployeeDocument mengirimkan isi file dokumen dari storage sebagai attachment.
+// Dengan query signed_url=true, dikembalikan URL download sementara (15 menit) sebagai gantinya.
+func streamEmployeeDocument(c echo.Context, document models.EmployeeDocument) error {
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	if c.QueryParam("signed_url") == "true" {
+		expiresAt := time.Now().Add(15 * time.Minute)
+		signedURL, err := fileStorage.SignedURL(document.StorageKey, 15*time.Minute)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create download URL"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Download URL created successfully",
+			"url":        signedURL,
+			"expires_at": expiresAt,
+		})
+	}
+
+	reader, err := fileStorage.Open(document.StorageKey)
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Document file not found"}
+		return c.JSON(http.StatusNotFound, errorResponse)
+	}
+	defer reader.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", document.FileName))
+	return c.Stream(http.StatusOK, document.ContentType, reader)
+}
+
+// deleteEmployeeDocument menghapus file pada storage beserta datanya
+func deleteEmployeeDocument(db *gorm.DB, document models.EmployeeDocument) {
+	fileStorage, err := helper.NewFileStorage()
+	if err == nil {
+		if err := fileStorage.Delete(document.StorageKey); err != nil {
+			fmt.Println("Failed to delete document file:", err)
+		}
+	}
+	db.Delete(&document)
+}
+
+// SendDocumentExpiryReminders mengirim pengingat ke karyawan dan HR 30, 14 dan 7 hari sebelum dokumen kedaluwarsa (dijalankan harian)
+func SendDocumentExpiryReminders(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		fmt.Println("Failed to load timezone:", err)
+		return
+	}
+	today := time.Now().In(loc)
+
+	var admins []models.Admin
+	db.Where("is_admin_hr = ?", true).Find(&admins)
+
+	sentCount := 0
+	for _, days := range expiryReminderDays {
+		targetDate := today.AddDate(0, 0, days).Format("2006-01-02")
+
+		var documents []models.EmployeeDocument
+		db.Where("expiry_date = ? AND (last_reminder_days = 0 OR last_reminder_days > ?)", targetDate, days).Find(&documents)
+
+		for _, document := range documents {
+			var employee models.Employee
+			if db.First(&employee, document.EmployeeID).Error == nil {
+				if err := helper.SendDocumentExpiryReminder(employee.Email, employee.FirstName+" "+employee.LastName, document.FullNameEmployee, document.Title, document.ExpiryDate, days); err != nil {
+					fmt.Println("Failed to send document expiry reminder:", err)
+				}
+			}
+
+			for _, admin := range admins {
+				if err := helper.SendDocumentExpiryReminder(admin.Email, admin.FirstName+" "+admin.LastName, document.FullNameEmployee, document.Title, document.ExpiryDate, days); err != nil {
+					fmt.Println("Failed to send document expiry reminder:", err)
+				}
+			}
+
+			db.Model(&document).Update("last_reminder_days", days)
+			sentCount++
+		}
+	}
+
+	log.Printf("Document reminder job finished: %d reminders sent\n", sentCount)
+}
+
+func CreateDocumentTypeByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var documentType models.DocumentType
+		if err := c.Bind(&documentType); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(documentType.Name) < 2 || len(documentType.Name) > 50 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Document type name must be between 2 and 50 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var existingCount int64
+		db.Model(&models.DocumentType{}).Where("name ILIKE ?", documentType.Name).Count(&existingCount)
+		if existingCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Document type already exists"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		documentType.CreatedAt = &currentTime
+
+		db.Create(&documentType)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Document type created successfully",
+			"data":    documentType,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+// GetAllDocumentTypes dapat diakses admin maupun karyawan untuk memilih jenis dokumen saat upload
+func GetAllDocumentTypes(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		if _, err := middleware.VerifyToken(tokenString, secretKey); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var documentTypes []models.DocumentType
+		if err := db.Order("name ASC").Find(&documentTypes).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching document types"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Document types retrieved successfully",
+			"data":    documentTypes,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func UpdateDocumentTypeByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var documentType models.DocumentType
+		result = db.First(&documentType, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Document type not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var updatedDocumentType struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			HasExpiry   *bool  `json:"has_expiry"`
+		}
+		if err := c.Bind(&updatedDocumentType); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if updatedDocumentType.Name != "" {
+			if len(updatedDocumentType.Name) < 2 || len(updatedDocumentType.Name) > 50 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Document type name must be between 2 and 50 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			documentType.Name = updatedDocumentType.Name
+		}
+		if updatedDocumentType.Description != "" {
+			documentType.Description = updatedDocumentType.Description
+		}
+		if updatedDocumentType.HasExpiry != nil {
+			documentType.HasExpiry = *updatedDocumentType.HasExpiry
+		}
+
+		db.Save(&documentType)
+		db.Model(&models.EmployeeDocument{}).Where("document_type_id = ?", documentType.ID).Update("document_type", documentType.Name)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Document type updated successfully",
+			"data":    documentType,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func DeleteDocumentTypeByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var documentType models.DocumentType
+		result = db.First(&documentType, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Document type not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var documentCount int64
+		db.Model(&models.EmployeeDocument{}).Where("document_type_id = ?", documentType.ID).Count(&documentCount)
+		if documentCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Document type is still used by employee documents"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		db.Delete(&documentType)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Document type deleted successfully",
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func UploadEmployeeDocumentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.FormValue("employee_id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid employee ID. Employee not found."}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		document, status, message := storeEmployeeDocument(c, db, employee, "Admin", adminUser.ID)
+		if message != "" {
+			errorResponse := helper.ErrorResponse{Code: status, Message: message}
+			return c.JSON(status, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Employee document uploaded successfully",
+			"data":    document,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+func GetAllEmployeeDocumentsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.EmployeeDocument{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("full_name_employee ILIKE ? OR title ILIKE ? OR document_number ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+		if employeeID := c.QueryParam("employee_id"); employeeID != "" {
+			query = query.Where("employee_id = ?", employeeID)
+		}
+		if documentTypeID := c.QueryParam("document_type_id"); documentTypeID != "" {
+			query = query.Where("document_type_id = ?", documentTypeID)
+		}
+		// expiring_within=N menampilkan dokumen yang kedaluwarsa dalam N hari ke depan
+		if expiringWithin, err := strconv.Atoi(c.QueryParam("expiring_within")); err == nil && expiringWithin > 0 {
+			today := time.Now()
+			query = query.Where("expiry_date <> ? AND expiry_date BETWEEN ? AND ?", "", today.Format("2006-01-02"), today.AddDate(0, 0, expiringWithin).Format("2006-01-02"))
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var documents []models.EmployeeDocument
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&documents).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching employee documents"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employee documents retrieved successfully",
+			"data":    documents,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func DownloadEmployeeDocumentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var document models.EmployeeDocument
+		result = db.First(&document, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee document not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamEmployeeDocument(c, document)
+	}
+}
+
+func DeleteEmployeeDocumentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var document models.EmployeeDocument
+		result = db.First(&document, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee document not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		deleteEmployeeDocument(db, document)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employee document deleted successfully",
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
@@ -78,6 +78,8 @@ func EmployeeProfile(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			"state_province":              employee.StateProvince,
 			"zip_postal_code":             employee.ZipPostalCode,
 			"bio":                         employee.Bio,
+			"avatar_url":                  employee.AvatarURL,
+			"profile_photo_url":           employee.ProfilePhotoURL,
 			"facebook_url":                employee.FacebookURL,
 			"instagram_url":               employee.InstagramURL,
 			"twitter_url":                 employee.TwitterURL,
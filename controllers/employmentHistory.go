@@ -0,0 +1,504 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmploymentChangeRequest adalah body untuk perubahan mutasi, promosi atau gaji dengan tanggal efektif
+type EmploymentChangeRequest struct {
+	DepartmentID  uint    `json:"department_id"`
+	DesignationID uint    `json:"designation_id"`
+	RoleID        uint    `json:"role_id"`
+	ShiftID       uint    `json:"shift_id"`
+	BasicSalary   float64 `json:"basic_salary"`
+	EffectiveDate string  `json:"effective_date"` // Format: yyyy-mm-dd
+	Reason        string  `json:"reason"`
+}
+
+// employmentChanges membandingkan data karyawan sebelum dan sesudah perubahan,
+// lalu mengembalikan satu riwayat untuk setiap field kepegawaian yang berubah
+func employmentChanges(previous, current models.Employee) []models.EmploymentHistory {
+	var histories []models.EmploymentHistory
+	newHistory := func(changeType string) models.EmploymentHistory {
+		return models.EmploymentHistory{
+			EmployeeID:       current.ID,
+			FullNameEmployee: current.FirstName + " " + current.LastName,
+			ChangeType:       changeType,
+		}
+	}
+
+	if previous.DepartmentID != current.DepartmentID {
+		history := newHistory("Department")
+		history.OldValueID, history.OldValue = previous.DepartmentID, previous.Department
+		history.NewValueID, history.NewValue = current.DepartmentID, current.Department
+		histories = append(histories, history)
+	}
+	if previous.DesignationID != current.DesignationID {
+		history := newHistory("Designation")
+		history.OldValueID, history.OldValue = previous.DesignationID, previous.Designation
+		history.NewValueID, history.NewValue = current.DesignationID, current.Designation
+		histories = append(histories, history)
+	}
+	if previous.RoleID != current.RoleID {
+		history := newHistory("Role")
+		history.OldValueID, history.OldValue = previous.RoleID, previous.Role
+		history.NewValueID, history.NewValue = current.RoleID, current.Role
+		histories = append(histories, history)
+	}
+	if previous.ShiftID != current.ShiftID {
+		history := newHistory("Shift")
+		history.OldValueID, history.OldValue = previous.ShiftID, previous.Shift
+		history.NewValueID, history.NewValue = current.ShiftID, current.Shift
+		histories = append(histories, history)
+	}
+	if previous.BasicSalary != current.BasicSalary {
+		history := newHistory("Salary")
+		history.OldSalary, history.NewSalary = previous.BasicSalary, current.BasicSalary
+		history.OldValue = fmt.Sprintf("%.2f", previous.BasicSalary)
+		history.NewValue = fmt.Sprintf("%.2f", current.BasicSalary)
+		histories = append(histories, history)
+	}
+
+	return histories
+}
+
+// applyEmploymentHistory menerapkan satu riwayat perubahan ke data karyawan
+func applyEmploymentHistory(employee *models.Employee, history models.EmploymentHistory) {
+	switch history.ChangeType {
+	case "Department":
+		employee.DepartmentID, employee.Department = history.NewValueID, history.NewValue
+	case "Designation":
+		employee.DesignationID, employee.Designation = history.NewValueID, history.NewValue
+	case "Role":
+		employee.RoleID, employee.Role = history.NewValueID, history.NewValue
+	case "Shift":
+		employee.ShiftID, employee.Shift = history.NewValueID, history.NewValue
+	case "Salary":
+		employee.BasicSalary = history.NewSalary
+	}
+}
+
+// recordEmploymentChanges menyimpan riwayat perubahan yang langsung berlaku hari ini,
+// dipakai saat data karyawan diubah lewat UpdateEmployeeAccountByAdmin
+func recordEmploymentChanges(db *gorm.DB, previous, current models.Employee, adminUser models.Admin, reason string) {
+	histories := employmentChanges(previous, current)
+	if len(histories) == 0 {
+		return
+	}
+
+	currentTime := time.Now()
+	for i := range histories {
+		histories[i].EffectiveDate = currentTime.Format("2006-01-02")
+		histories[i].Reason = reason
+		histories[i].Status = "Applied"
+		histories[i].ApprovedByAdminID = adminUser.ID
+		histories[i].ApprovedByAdminName = adminUser.FirstName + " " + adminUser.LastName
+		histories[i].AppliedAt = &currentTime
+		histories[i].CreatedAt = &currentTime
+	}
+
+	if err := db.Create(&histories).Error; err != nil {
+		fmt.Println("Failed to record employment history:", err)
+	}
+}
+
+// proratedBasicSalary menghitung gaji pokok bulan berjalan secara proporsional (berdasarkan hari kalender)
+// jika ada perubahan gaji yang berlaku di tengah periode
+func proratedBasicSalary(db *gorm.DB, employee models.Employee, period time.Time) float64 {
+	monthStart := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	nextMonthStart := monthStart.AddDate(0, 1, 0)
+	daysInMonth := nextMonthStart.Sub(monthStart).Hours() / 24
+
+	var salaryChanges []models.EmploymentHistory
+	db.Where("employee_id = ? AND change_type = ? AND status = ? AND effective_date > ? AND effective_date < ?",
+		employee.ID, "Salary", "Applied", monthStart.Format("2006-01-02"), nextMonthStart.Format("2006-01-02")).
+		Order("effective_date ASC, id ASC").
+		Find(&salaryChanges)
+
+	if len(salaryChanges) == 0 {
+		return employee.BasicSalary
+	}
+
+	salary := salaryChanges[0].OldSalary
+	segmentStart := monthStart
+	proratedSalary := 0.0
+	for _, change := range salaryChanges {
+		effectiveDate, err := time.Parse("2006-01-02", change.EffectiveDate)
+		if err != nil {
+			continue
+		}
+		days := effectiveDate.Sub(segmentStart).Hours() / 24
+		proratedSalary += salary * days / daysInMonth
+		salary = change.NewSalary
+		segmentStart = effectiveDate
+	}
+	days := nextMonthStart.Sub(segmentStart).Hours() / 24
+	proratedSalary += salary * days / daysInMonth
+
+	return proratedSalary
+}
+
+// ApplyScheduledEmploymentChanges menerapkan perubahan terjadwal yang tanggal efektifnya sudah tiba (dijalankan harian)
+func ApplyScheduledEmploymentChanges(db *gorm.DB) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		fmt.Println("Failed to load timezone:", err)
+		return
+	}
+	today := time.Now().In(loc).Format("2006-01-02")
+
+	var histories []models.EmploymentHistory
+	if err := db.Where("status = ? AND effective_date <= ?", "Scheduled", today).Order("effective_date ASC, id ASC").Find(&histories).Error; err != nil {
+		fmt.Println("Failed to fetch scheduled employment changes:", err)
+		return
+	}
+
+	for _, history := range histories {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var employee models.Employee
+			if err := tx.First(&employee, history.EmployeeID).Error; err != nil {
+				return err
+			}
+			applyEmploymentHistory(&employee, history)
+			if err := tx.Save(&employee).Error; err != nil {
+				return err
+			}
+
+			currentTime := time.Now()
+			history.Status = "Applied"
+			history.AppliedAt = &currentTime
+			return tx.Save(&history).Error
+		})
+		if err != nil {
+			fmt.Println("Failed to apply employment change", history.ID, ":", err)
+		}
+	}
+}
+
+// CreateEmploymentChangeByAdmin mencatat mutasi, promosi atau perubahan gaji dengan tanggal efektif.
+// Perubahan dengan tanggal efektif hari ini atau sebelumnya langsung diterapkan, sisanya dijadwalkan.
+func CreateEmploymentChangeByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request EmploymentChangeRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		effectiveDate, err := time.Parse("2006-01-02", request.EffectiveDate)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid effective date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(request.Reason) < 5 || len(request.Reason) > 3000 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Reason must be between 5 and 3000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Bangun data karyawan setelah perubahan untuk dibandingkan dengan data saat ini
+		target := employee
+		if request.DepartmentID != 0 {
+			var department models.Department
+			if err := db.First(&department, "id = ?", request.DepartmentID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid department ID. Department not found."}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			target.DepartmentID = department.ID
+			target.Department = department.DepartmentName
+		}
+		if request.DesignationID != 0 {
+			var designation models.Designation
+			if err := db.First(&designation, "id = ?", request.DesignationID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid designation ID. Designation not found."}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			target.DesignationID = designation.ID
+			target.Designation = designation.DesignationName
+		}
+		if request.RoleID != 0 {
+			var role models.Role
+			if err := db.First(&role, "id = ?", request.RoleID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid role ID. Role not found."}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			target.RoleID = role.ID
+			target.Role = role.RoleName
+		}
+		if request.ShiftID != 0 {
+			var shift models.Shift
+			if err := db.First(&shift, "id = ?", request.ShiftID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid shift ID. Shift not found."}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			target.ShiftID = shift.ID
+			target.Shift = shift.ShiftName
+		}
+		if request.BasicSalary < 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Basic salary must not be negative"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if request.BasicSalary != 0 {
+			target.BasicSalary = request.BasicSalary
+		}
+
+		histories := employmentChanges(employee, target)
+		if len(histories) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "No employment changes found in the request"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		today := time.Now().In(loc).Format("2006-01-02")
+		applyNow := effectiveDate.Format("2006-01-02") <= today
+
+		currentTime := time.Now()
+		for i := range histories {
+			histories[i].EffectiveDate = effectiveDate.Format("2006-01-02")
+			histories[i].Reason = request.Reason
+			histories[i].Status = "Scheduled"
+			histories[i].ApprovedByAdminID = adminUser.ID
+			histories[i].ApprovedByAdminName = adminUser.FirstName + " " + adminUser.LastName
+			histories[i].CreatedAt = &currentTime
+			if applyNow {
+				histories[i].Status = "Applied"
+				histories[i].AppliedAt = &currentTime
+			}
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&histories).Error; err != nil {
+				return err
+			}
+			if applyNow {
+				return tx.Save(&target).Error
+			}
+			return nil
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save employment change"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		message := "Employment change scheduled successfully"
+		if applyNow {
+			message = "Employment change applied successfully"
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": message,
+			"data":    histories,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+// GetEmploymentTimelineByAdmin menampilkan riwayat kepegawaian seorang karyawan secara kronologis
+func GetEmploymentTimelineByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		query := db.Model(&models.EmploymentHistory{}).Where("employee_id = ?", employee.ID)
+		if changeType := c.QueryParam("change_type"); changeType != "" {
+			query = query.Where("change_type = ?", changeType)
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var histories []models.EmploymentHistory
+		if err := query.Order("effective_date ASC, id ASC").Find(&histories).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching employment history"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		hireDate := ""
+		if employee.CreatedAt != nil {
+			// Karyawan hasil rekrutmen memiliki CreatedAt awal hari join date (Asia/Jakarta)
+			createdAt := *employee.CreatedAt
+			if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+				createdAt = createdAt.In(loc)
+			}
+			hireDate = createdAt.Format("2006-01-02")
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employment timeline retrieved successfully",
+			"employee": map[string]interface{}{
+				"id":             employee.ID,
+				"full_name":      employee.FirstName + " " + employee.LastName,
+				"hire_date":      hireDate,
+				"department_id":  employee.DepartmentID,
+				"department":     employee.Department,
+				"designation_id": employee.DesignationID,
+				"designation":    employee.Designation,
+				"role_id":        employee.RoleID,
+				"role":           employee.Role,
+				"shift_id":       employee.ShiftID,
+				"shift":          employee.Shift,
+				"basic_salary":   employee.BasicSalary,
+			},
+			"data": histories,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+// CancelEmploymentChangeByAdmin membatalkan perubahan yang masih terjadwal
+func CancelEmploymentChangeByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		historyID, err := strconv.Atoi(c.Param("history_id"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid employment history ID"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var history models.EmploymentHistory
+		result = db.Where("id = ? AND employee_id = ?", historyID, c.Param("id")).First(&history)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employment history not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if history.Status != "Scheduled" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only scheduled employment changes can be cancelled"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		history.Status = "Cancelled"
+		db.Save(&history)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employment change cancelled successfully",
+			"data":    history,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
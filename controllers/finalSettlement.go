@@ -0,0 +1,543 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// severanceRule adalah pengali uang pesangon (UP) dan uang penghargaan masa kerja (UPMK) menurut PP 35/2021
+type severanceRule struct {
+	SeveranceMultiplier    float64
+	ServiceAwardMultiplier float64
+}
+
+// terminationReasons memetakan alasan PHK ke pengali UP dan UPMK; "End of Contract" mendapat kompensasi PKWT
+var terminationReasons = map[string]severanceRule{
+	"Resignation":                 {0, 0},
+	"End of Contract":             {0, 0},
+	"Efficiency":                  {1, 1},
+	"Efficiency Due to Loss":      {0.5, 1},
+	"Company Closure":             {1, 1},
+	"Company Closure Due to Loss": {0.5, 1},
+	"Merger":                      {1, 1},
+	"Bankruptcy":                  {0.5, 1},
+	"Retirement":                  {1.75, 1},
+	"Death":                       {2, 1},
+	"Long Illness":                {2, 1},
+	"Violation":                   {0.5, 1},
+	"Serious Violation":           {0, 0},
+}
+
+type FinalSettlementRequest struct {
+	TerminationReason  string   `json:"termination_reason"`
+	JoinDate           string   `json:"join_date"` // Default: kontrak pertama, tanggal onboarding atau tanggal data karyawan dibuat
+	FixedAllowances    float64  `json:"fixed_allowances"`
+	SalaryAlreadyPaid  bool     `json:"salary_already_paid"`   // true jika gaji bulan terakhir sudah dibayar melalui payroll
+	WorkingDaysPerWeek int      `json:"working_days_per_week"` // 5 (default) atau 6, dasar upah harian
+	LeaveTypeIDs       []uint   `json:"leave_type_ids"`        // Default: jenis cuti bernama annual/tahunan
+	UnusedLeaveDays    *float64 `json:"unused_leave_days"`     // Mengganti perhitungan sisa cuti otomatis
+	OtherEarnings      float64  `json:"other_earnings"`        // Misalnya uang pisah sesuai perjanjian kerja
+	OtherDeductions    float64  `json:"other_deductions"`
+	Notes              string   `json:"notes"`
+}
+
+func terminationReasonList() string {
+	var reasons []string
+	for reason := range terminationReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, ", ")
+}
+
+// severanceMonths mengikuti tabel uang pesangon PP 35/2021 Pasal 40 ayat (2)
+func severanceMonths(serviceYears int) float64 {
+	if serviceYears >= 8 {
+		return 9
+	}
+	return float64(serviceYears + 1)
+}
+
+// serviceAwardMonths mengikuti tabel uang penghargaan masa kerja PP 35/2021 Pasal 40 ayat (3)
+func serviceAwardMonths(serviceYears int) float64 {
+	switch {
+	case serviceYears < 3:
+		return 0
+	case serviceYears >= 24:
+		return 10
+	default:
+		return float64(serviceYears/3 + 1)
+	}
+}
+
+// serviceLength menghitung masa kerja dalam tahun dan sisa bulan penuh sampai hari kerja terakhir
+func serviceLength(joinDate, lastWorkingDate time.Time) (int, int) {
+	end := lastWorkingDate.AddDate(0, 0, 1)
+	months := (end.Year()-joinDate.Year())*12 + int(end.Month()) - int(joinDate.Month())
+	if end.Day() < joinDate.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	return months / 12, months % 12
+}
+
+// employeeJoinDate mengambil tanggal mulai kontrak pertama, tanggal onboarding atau tanggal data karyawan dibuat
+func employeeJoinDate(db *gorm.DB, employee models.Employee) string {
+	var contract models.EmploymentContract
+	if err := db.Where("employee_id = ? AND start_date <> ?", employee.ID, "").Order("start_date ASC").First(&contract).Error; err == nil {
+		return contract.StartDate
+	}
+
+	var onboarding models.OnboardingChecklist
+	if err := db.Where("employee_id = ? AND join_date <> ?", employee.ID, "").Order("id ASC").First(&onboarding).Error; err == nil {
+		return onboarding.JoinDate
+	}
+
+	if employee.CreatedAt != nil {
+		return employee.CreatedAt.Format("2006-01-02")
+	}
+	return ""
+}
+
+// unusedAnnualLeaveDays menghitung sisa cuti tahunan secara proporsional sampai bulan terakhir bekerja,
+// hak cuti tahunan baru berlaku setelah 12 bulan bekerja
+func unusedAnnualLeaveDays(db *gorm.DB, employeeID uint, leaveTypeIDs []uint, lastWorkingDate time.Time, totalServiceMonths int) float64 {
+	if totalServiceMonths < 12 {
+		return 0
+	}
+
+	var leaveTypes []models.LeaveRequestType
+	if len(leaveTypeIDs) > 0 {
+		db.Where("id IN ?", leaveTypeIDs).Find(&leaveTypes)
+	} else {
+		db.Where("leave_type ILIKE ? OR leave_type ILIKE ?", "%annual%", "%tahunan%").Find(&leaveTypes)
+	}
+	if len(leaveTypes) == 0 {
+		return 0
+	}
+
+	var typeIDs []uint
+	entitlement := 0
+	for _, leaveType := range leaveTypes {
+		typeIDs = append(typeIDs, leaveType.ID)
+		entitlement += leaveType.DaysPerYears
+	}
+	accrued := math.Floor(float64(entitlement) * float64(lastWorkingDate.Month()) / 12)
+
+	yearStart := time.Date(lastWorkingDate.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	var taken float64
+	db.Model(&models.LeaveRequest{}).
+		Where("employee_id = ? AND leave_type_id IN ? AND status = ? AND start_date >= ? AND start_date <= ?",
+			employeeID, typeIDs, "Approved", yearStart.Format("2006-01-02"), lastWorkingDate.Format("2006-01-02")).
+		Select("COALESCE(SUM(days), 0)").Scan(&taken)
+
+	return math.Max(accrued-taken, 0)
+}
+
+// outstandingRecoveries menghitung sisa pinjaman dan kasbon yang belum lunas
+func outstandingRecoveries(db *gorm.DB, employeeID uint) (float64, float64) {
+	var loans []models.RequestLoan
+	db.Where("employee_id = ? AND status = ? AND remaining > ?", employeeID, "Approved", 0).Find(&loans)
+	loanRecovery := 0
+	for _, loan := range loans {
+		loanRecovery += loan.Remaining
+	}
+
+	var advances []models.AdvanceSalary
+	db.Where("employee_id = ? AND status = ? AND amount > paid", employeeID, "Approved").Find(&advances)
+	advanceRecovery := 0
+	for _, advance := range advances {
+		advanceRecovery += advance.Amount - advance.Paid
+	}
+
+	return float64(loanRecovery), float64(advanceRecovery)
+}
+
+// calculateFinalSettlement menghitung gaji terakhir, penggantian cuti, pesangon dan potongan untuk checklist offboarding
+func calculateFinalSettlement(db *gorm.DB, checklist models.OffboardingChecklist, employee models.Employee, request FinalSettlementRequest) (models.FinalSettlement, error) {
+	settlement := models.FinalSettlement{
+		ChecklistID:       checklist.ID,
+		EmployeeID:        employee.ID,
+		FullName:          employee.FullName,
+		TerminationReason: request.TerminationReason,
+		JoinDate:          request.JoinDate,
+		LastWorkingDate:   checklist.LastWorkingDate,
+		FixedAllowances:   request.FixedAllowances,
+		SalaryAlreadyPaid: request.SalaryAlreadyPaid,
+		OtherEarnings:     request.OtherEarnings,
+		OtherDeductions:   request.OtherDeductions,
+		Notes:             request.Notes,
+		Status:            "Draft",
+	}
+	if settlement.TerminationReason == "" {
+		settlement.TerminationReason = checklist.TerminationReason
+	}
+	rule, ok := terminationReasons[settlement.TerminationReason]
+	if !ok {
+		return settlement, errors.New("Invalid termination reason. Allowed values: " + terminationReasonList())
+	}
+	if request.FixedAllowances < 0 || request.OtherEarnings < 0 || request.OtherDeductions < 0 {
+		return settlement, errors.New("Allowances, other earnings and other deductions must not be negative")
+	}
+
+	if settlement.JoinDate == "" {
+		settlement.JoinDate = employeeJoinDate(db, employee)
+	}
+	joinDate, err := time.Parse("2006-01-02", settlement.JoinDate)
+	if err != nil {
+		return settlement, errors.New("Invalid join_date format. Required format: yyyy-mm-dd")
+	}
+	lastWorkingDate, err := time.Parse("2006-01-02", settlement.LastWorkingDate)
+	if err != nil {
+		return settlement, errors.New("Offboarding checklist has an invalid last working date")
+	}
+	if joinDate.After(lastWorkingDate) {
+		return settlement, errors.New("Join date must be before the last working date")
+	}
+	settlement.ServiceYears, settlement.ServiceMonths = serviceLength(joinDate, lastWorkingDate)
+	totalServiceMonths := settlement.ServiceYears*12 + settlement.ServiceMonths
+
+	// Gaji bulan terakhir diprorata sampai hari kerja terakhir
+	settlement.MonthlySalary = proratedBasicSalary(db, employee, lastWorkingDate)
+	monthStart := time.Date(lastWorkingDate.Year(), lastWorkingDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	settlement.DaysInMonth = monthStart.AddDate(0, 1, -1).Day()
+	settlement.DaysWorked = lastWorkingDate.Day()
+	if joinDate.After(monthStart) {
+		settlement.DaysWorked = lastWorkingDate.Day() - joinDate.Day() + 1
+	}
+	if !settlement.SalaryAlreadyPaid {
+		settlement.ProratedSalary = math.Round((settlement.MonthlySalary+settlement.FixedAllowances)*float64(settlement.DaysWorked)/float64(settlement.DaysInMonth)*100) / 100
+	}
+
+	// Upah dasar pesangon adalah gaji pokok ditambah tunjangan tetap
+	monthlyWage := employee.BasicSalary + settlement.FixedAllowances
+
+	// Penggantian cuti tahunan yang belum diambil
+	workingDaysPerWeek := request.WorkingDaysPerWeek
+	if workingDaysPerWeek == 0 {
+		workingDaysPerWeek = 5
+	}
+	switch workingDaysPerWeek {
+	case 5:
+		settlement.DailyWage = math.Round(monthlyWage/21*100) / 100
+	case 6:
+		settlement.DailyWage = math.Round(monthlyWage/25*100) / 100
+	default:
+		return settlement, errors.New("Working days per week must be 5 or 6")
+	}
+	if request.UnusedLeaveDays != nil {
+		if *request.UnusedLeaveDays < 0 {
+			return settlement, errors.New("Unused leave days must not be negative")
+		}
+		settlement.UnusedLeaveDays = *request.UnusedLeaveDays
+	} else {
+		settlement.UnusedLeaveDays = unusedAnnualLeaveDays(db, employee.ID, request.LeaveTypeIDs, lastWorkingDate, totalServiceMonths)
+	}
+	settlement.LeaveEncashment = math.Round(settlement.DailyWage*settlement.UnusedLeaveDays*100) / 100
+
+	// Pesangon dan penghargaan masa kerja
+	settlement.SeveranceMultiplier = rule.SeveranceMultiplier
+	if rule.SeveranceMultiplier > 0 {
+		settlement.SeveranceMonths = severanceMonths(settlement.ServiceYears)
+		settlement.SeverancePay = math.Round(rule.SeveranceMultiplier*settlement.SeveranceMonths*monthlyWage*100) / 100
+	}
+	if rule.ServiceAwardMultiplier > 0 {
+		settlement.ServiceAwardMonths = serviceAwardMonths(settlement.ServiceYears)
+		settlement.ServiceAwardPay = math.Round(rule.ServiceAwardMultiplier*settlement.ServiceAwardMonths*monthlyWage*100) / 100
+	}
+
+	// Kompensasi PKWT (PP 35/2021 Pasal 15-16): masa kerja PKWT dalam bulan / 12 x upah, minimal 1 bulan masa kerja
+	if settlement.TerminationReason == "End of Contract" {
+		contractMonths := totalServiceMonths
+		var contract models.EmploymentContract
+		if err := db.Where("employee_id = ? AND contract_type = ?", employee.ID, "PKWT").Order("start_date DESC").First(&contract).Error; err == nil {
+			if contractStart, err := time.Parse("2006-01-02", contract.StartDate); err == nil && !contractStart.After(lastWorkingDate) {
+				contractYears, remainingMonths := serviceLength(contractStart, lastWorkingDate)
+				contractMonths = contractYears*12 + remainingMonths
+			}
+		}
+		if contractMonths >= 1 {
+			settlement.ContractCompensation = math.Round(float64(contractMonths)/12*monthlyWage*100) / 100
+		}
+	}
+
+	// Potongan pinjaman dan kasbon yang belum lunas
+	settlement.LoanRecovery, settlement.AdvanceRecovery = outstandingRecoveries(db, employee.ID)
+
+	settlement.GrossAmount = math.Round((settlement.ProratedSalary+settlement.LeaveEncashment+settlement.SeverancePay+
+		settlement.ServiceAwardPay+settlement.ContractCompensation+settlement.OtherEarnings)*100) / 100
+	settlement.TotalDeductions = math.Round((settlement.LoanRecovery+settlement.AdvanceRecovery+settlement.OtherDeductions)*100) / 100
+	settlement.NetAmount = math.Round((settlement.GrossAmount-settlement.TotalDeductions)*100) / 100
+
+	return settlement, nil
+}
+
+// CalculateFinalSettlementByAdmin menghitung (atau menghitung ulang) final settlement selama masih Draft
+func CalculateFinalSettlementByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		if err := db.First(&checklist, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Offboarding checklist not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var existing models.FinalSettlement
+		hasExisting := db.Where("checklist_id = ?", checklist.ID).First(&existing).Error == nil
+		if hasExisting && existing.Status == "Finalized" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Final settlement is already finalized"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request FinalSettlementRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var employee models.Employee
+		if err := db.First(&employee, checklist.EmployeeID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		settlement, err := calculateFinalSettlement(db, checklist, employee, request)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		settlement.CalculatedByID = adminUser.ID
+		settlement.UpdatedAt = currentTime
+		if hasExisting {
+			settlement.ID = existing.ID
+			settlement.CreatedAt = existing.CreatedAt
+		} else {
+			settlement.CreatedAt = &currentTime
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&settlement).Error; err != nil {
+				return err
+			}
+			if checklist.TerminationReason != settlement.TerminationReason {
+				return tx.Model(&models.OffboardingChecklist{}).Where("id = ?", checklist.ID).Updates(map[string]interface{}{
+					"termination_reason": settlement.TerminationReason,
+					"updated_at":         currentTime,
+				}).Error
+			}
+			return nil
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save final settlement"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Final settlement calculated successfully",
+			"settlement": settlement,
+		})
+	}
+}
+
+// FinalizeFinalSettlementByAdmin mengunci perhitungan, melunasi pinjaman dan kasbon, dan menyelesaikan tugas final settlement
+func FinalizeFinalSettlementByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var settlement models.FinalSettlement
+		if err := db.Where("checklist_id = ?", c.Param("id")).First(&settlement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Final settlement has not been calculated"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if settlement.Status == "Finalized" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Final settlement is already finalized"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Pastikan potongan masih sesuai, misalnya jika payroll sempat memotong cicilan setelah perhitungan
+		loanRecovery, advanceRecovery := outstandingRecoveries(db, settlement.EmployeeID)
+		if loanRecovery != settlement.LoanRecovery || advanceRecovery != settlement.AdvanceRecovery {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Outstanding loans or salary advances changed since the calculation, please recalculate the settlement"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		settlement.Status = "Finalized"
+		settlement.FinalizedByID = adminUser.ID
+		settlement.FinalizedAt = &currentTime
+		settlement.UpdatedAt = currentTime
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.RequestLoan{}).
+				Where("employee_id = ? AND status = ? AND remaining > ?", settlement.EmployeeID, "Approved", 0).
+				Updates(map[string]interface{}{"paid": gorm.Expr("amount"), "remaining": 0}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.AdvanceSalary{}).
+				Where("employee_id = ? AND status = ? AND amount > paid", settlement.EmployeeID, "Approved").
+				Update("paid", gorm.Expr("amount")).Error; err != nil {
+				return err
+			}
+			return tx.Save(&settlement).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to finalize final settlement"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		if err := preloadOffboardingTasks(db).First(&checklist, settlement.ChecklistID).Error; err == nil {
+			for i := range checklist.Tasks {
+				task := &checklist.Tasks[i]
+				if task.Category == "Final Settlement" && task.Status != "Completed" {
+					note := fmt.Sprintf("Final settlement finalized with net amount %s", helper.FormatToIDR(settlement.NetAmount))
+					completeOffboardingTask(db, task, "Admin", adminUser.ID, adminUser.Username, note)
+				}
+			}
+			refreshOffboardingChecklistStatus(db, &checklist)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":             http.StatusOK,
+			"error":            false,
+			"message":          "Final settlement finalized successfully",
+			"settlement":       settlement,
+			"checklist_status": checklist.Status,
+		})
+	}
+}
+
+func streamFinalSettlementPDF(c echo.Context, settlement models.FinalSettlement) error {
+	pdfData, err := helper.GenerateFinalSettlementPDF(settlement)
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate final settlement PDF"}
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	fileName := fmt.Sprintf("final_settlement_%s.pdf", strings.ReplaceAll(strings.ToLower(settlement.FullName), " ", "_"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	return c.Stream(http.StatusOK, "application/pdf", bytes.NewReader(pdfData))
+}
+
+func DownloadFinalSettlementPDFByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var settlement models.FinalSettlement
+		if err := db.Where("checklist_id = ?", c.Param("id")).First(&settlement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Final settlement has not been calculated"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamFinalSettlementPDF(c, settlement)
+	}
+}
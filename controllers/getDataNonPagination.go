@@ -336,6 +336,7 @@ func GetAllEmployeesByAdminNonPagination(db *gorm.DB, secretKey []byte) echo.Han
 				PaySlipType:              emp.PaySlipType,
 				IsActive:                 *emp.IsActive,
 				PaidStatus:               emp.PaidStatus,
+				AvatarURL:                emp.AvatarURL,
 				MaritalStatus:            emp.MaritalStatus,
 				Religion:                 emp.Religion,
 				BloodGroup:               emp.BloodGroup,
@@ -14,18 +14,24 @@ import (
 )
 
 type HelpdeskResponse struct {
-	ID               uint       `gorm:"primaryKey" json:"id"`
-	Subject          string     `json:"subject"`
-	Priority         string     `json:"priority"`
-	DepartmentID     uint       `json:"department_id"`
-	DepartmentName   string     `json:"department_name"`
-	EmployeeID       uint       `json:"employee_id"`
-	EmployeeUsername string     `json:"employee_username"`
-	EmployeeFullName string     `json:"employee_full_name"`
-	Description      string     `json:"description"`
-	Status           string     `json:"status"`
-	CreatedAt        *time.Time `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	Subject            string     `json:"subject"`
+	Priority           string     `json:"priority"`
+	DepartmentID       uint       `json:"department_id"`
+	DepartmentName     string     `json:"department_name"`
+	EmployeeID         uint       `json:"employee_id"`
+	EmployeeUsername   string     `json:"employee_username"`
+	EmployeeFullName   string     `json:"employee_full_name"`
+	EmployeeAvatarURL  string     `json:"employee_avatar_url"`
+	AssignedAdminID    uint       `json:"assigned_admin_id"`
+	AssignedAdminName  string     `json:"assigned_admin_name"`
+	FirstResponseDueAt *time.Time `json:"first_response_due_at"`
+	ResolutionDueAt    *time.Time `json:"resolution_due_at"`
+	EscalationLevel    int        `json:"escalation_level"`
+	Description        string     `json:"description"`
+	Status             string     `json:"status"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 func CreateHelpdeskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
@@ -111,6 +117,9 @@ func CreateHelpdeskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		recordHelpdeskStatusChange(db, helpdesk.ID, "", helpdesk.Status, "Admin", adminUser.ID, adminUser.FirstName+" "+adminUser.LastName)
+		initHelpdeskSLA(db, &helpdesk)
+
 		// Send notification email to employee
 		err = helper.SendHelpdeskNotification(existingEmployee.Email, helpdesk.EmployeeFullName, helpdesk.Subject, helpdesk.Description)
 		if err != nil {
@@ -119,18 +128,24 @@ func CreateHelpdeskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Create HelpdeskResponse struct for response
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -364,18 +379,24 @@ func GetAllHelpdeskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		helpdeskResponses := make([]HelpdeskResponse, len(helpdesks))
 		for i, helpdesk := range helpdesks {
 			helpdeskResponses[i] = HelpdeskResponse{
-				ID:               helpdesk.ID,
-				Subject:          helpdesk.Subject,
-				Priority:         helpdesk.Priority,
-				DepartmentID:     helpdesk.DepartmentID,
-				DepartmentName:   helpdesk.DepartmentName,
-				EmployeeID:       helpdesk.EmployeeID,
-				EmployeeUsername: helpdesk.EmployeeUsername,
-				EmployeeFullName: helpdesk.EmployeeFullName,
-				Description:      helpdesk.Description,
-				Status:           helpdesk.Status,
-				CreatedAt:        helpdesk.CreatedAt,
-				UpdatedAt:        helpdesk.UpdatedAt,
+				ID:                 helpdesk.ID,
+				Subject:            helpdesk.Subject,
+				Priority:           helpdesk.Priority,
+				DepartmentID:       helpdesk.DepartmentID,
+				DepartmentName:     helpdesk.DepartmentName,
+				EmployeeID:         helpdesk.EmployeeID,
+				EmployeeUsername:   helpdesk.EmployeeUsername,
+				EmployeeFullName:   helpdesk.EmployeeFullName,
+				EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+				AssignedAdminID:    helpdesk.AssignedAdminID,
+				AssignedAdminName:  helpdesk.AssignedAdminName,
+				FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+				ResolutionDueAt:    helpdesk.ResolutionDueAt,
+				EscalationLevel:    helpdesk.EscalationLevel,
+				Description:        helpdesk.Description,
+				Status:             helpdesk.Status,
+				CreatedAt:          helpdesk.CreatedAt,
+				UpdatedAt:          helpdesk.UpdatedAt,
 			}
 		}
 
@@ -602,18 +623,24 @@ func GetHelpdeskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Map Helpdesk to HelpdeskResponse
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -749,6 +776,8 @@ func UpdateHelpdeskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			helpdesk.Subject = updatedHelpdesk.Subject
 		}
 
+		oldPriority := helpdesk.Priority
+		oldStatus := helpdesk.Status
 		if updatedHelpdesk.Priority != "" {
 			helpdesk.Priority = updatedHelpdesk.Priority
 		}
@@ -790,12 +819,15 @@ func UpdateHelpdeskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		currentTime := time.Now()
 		helpdesk.UpdatedAt = currentTime
+		updateHelpdeskSLAState(db, &helpdesk, oldPriority, oldStatus)
 
 		if err := db.Save(&helpdesk).Error; err != nil {
 			errorResponse := helper.Response{Code: http.StatusInternalServerError, Error: true, Message: "Failed to update helpdesk"}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		recordHelpdeskStatusChange(db, helpdesk.ID, oldStatus, helpdesk.Status, "Admin", adminUser.ID, adminUser.FirstName+" "+adminUser.LastName)
+
 		// Fetch related employee data from the database using the EmployeeID in helpdesk
 		var employee models.Employee
 		result = db.First(&employee, "id = ?", helpdesk.EmployeeID)
@@ -814,18 +846,24 @@ func UpdateHelpdeskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Map Helpdesk to HelpdeskResponse
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -1023,6 +1061,7 @@ func DeleteHelpdeskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
+		deleteHelpdeskThread(db, helpdesk.ID)
 		db.Delete(&helpdesk)
 		successResponse := map[string]interface{}{
 			"Code":    http.StatusOK,
@@ -1121,6 +1160,8 @@ func GetTicketStatsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			"message":         "Ticket counts by status and priority retrieved successfully",
 			"ticket_status":   ticketStatus,
 			"ticket_priority": ticketPriority,
+			"sla":             helpdeskSLAStats(db),
+			"deflection":      knowledgeBaseDeflectionStats(db),
 		}
 		return c.JSON(http.StatusOK, successResponse)
 	}
@@ -48,6 +48,25 @@ func CreateHelpdeskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		// Mode draft (?draft=true): tampilkan saran artikel knowledge base tanpa membuat tiket
+		if c.QueryParam("draft") == "true" {
+			if strings.TrimSpace(helpdesk.Subject) == "" {
+				errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Subject is required to get suggested articles"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			articles := suggestKnowledgeBaseArticles(db, helpdesk.Subject+" "+helpdesk.Description)
+			suggestion := createKnowledgeBaseSuggestion(db, employee, helpdesk.Subject, articles)
+
+			return c.JSON(http.StatusOK, map[string]interface{}{
+				"code":               http.StatusOK,
+				"error":              false,
+				"message":            "Suggested articles retrieved successfully",
+				"suggestion_id":      suggestion.ID,
+				"suggested_articles": articles,
+			})
+		}
+
 		if helpdesk.Subject == "" || helpdesk.Priority == "" || helpdesk.Description == "" || helpdesk.DepartmentID == 0 {
 			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "All fields are required"}
 			return c.JSON(http.StatusBadRequest, errorResponse)
@@ -85,6 +104,14 @@ func CreateHelpdeskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		recordHelpdeskStatusChange(db, helpdesk.ID, "", helpdesk.Status, "Employee", employee.ID, helpdesk.EmployeeFullName)
+		initHelpdeskSLA(db, &helpdesk)
+
+		// Tiket dibuat setelah melihat saran artikel, berarti tidak ter-deflect
+		if suggestionID := c.QueryParam("suggestion_id"); suggestionID != "" {
+			db.Model(&models.KnowledgeBaseSuggestion{}).Where("id = ? AND employee_id = ? AND deflected = ?", suggestionID, employee.ID, false).Update("helpdesk_id", helpdesk.ID)
+		}
+
 		// Send notification email to employee
 		err = helper.SendHelpdeskNotification(employee.Email, helpdesk.EmployeeFullName, helpdesk.Subject, helpdesk.Description)
 		if err != nil {
@@ -93,18 +120,24 @@ func CreateHelpdeskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Create HelpdeskResponse struct for response
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -286,18 +319,24 @@ func GetAllHelpdeskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		helpdeskResponses := make([]HelpdeskResponse, len(helpdeskList))
 		for i, helpdesk := range helpdeskList {
 			helpdeskResponses[i] = HelpdeskResponse{
-				ID:               helpdesk.ID,
-				Subject:          helpdesk.Subject,
-				Priority:         helpdesk.Priority,
-				DepartmentID:     helpdesk.DepartmentID,
-				DepartmentName:   helpdesk.DepartmentName,
-				EmployeeID:       helpdesk.EmployeeID,
-				EmployeeUsername: helpdesk.EmployeeUsername,
-				EmployeeFullName: helpdesk.EmployeeFullName,
-				Description:      helpdesk.Description,
-				Status:           helpdesk.Status,
-				CreatedAt:        helpdesk.CreatedAt,
-				UpdatedAt:        helpdesk.UpdatedAt,
+				ID:                 helpdesk.ID,
+				Subject:            helpdesk.Subject,
+				Priority:           helpdesk.Priority,
+				DepartmentID:       helpdesk.DepartmentID,
+				DepartmentName:     helpdesk.DepartmentName,
+				EmployeeID:         helpdesk.EmployeeID,
+				EmployeeUsername:   helpdesk.EmployeeUsername,
+				EmployeeFullName:   helpdesk.EmployeeFullName,
+				EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+				AssignedAdminID:    helpdesk.AssignedAdminID,
+				AssignedAdminName:  helpdesk.AssignedAdminName,
+				FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+				ResolutionDueAt:    helpdesk.ResolutionDueAt,
+				EscalationLevel:    helpdesk.EscalationLevel,
+				Description:        helpdesk.Description,
+				Status:             helpdesk.Status,
+				CreatedAt:          helpdesk.CreatedAt,
+				UpdatedAt:          helpdesk.UpdatedAt,
 			}
 		}
 
@@ -468,18 +507,24 @@ func GetHelpdeskByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -626,8 +671,9 @@ func UpdateHelpdeskByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			helpdesk.DepartmentName = existingDepartment.DepartmentName
 		}
 
-		if updatedHelpdesk.Priority != "" {
+		if updatedHelpdesk.Priority != "" && updatedHelpdesk.Priority != helpdesk.Priority {
 			helpdesk.Priority = updatedHelpdesk.Priority
+			updateHelpdeskSLAState(db, &helpdesk, "", helpdesk.Status)
 		}
 
 		if updatedHelpdesk.Description != "" {
@@ -644,18 +690,24 @@ func UpdateHelpdeskByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFun
 		db.Save(&helpdesk)
 
 		helpdeskResponse := HelpdeskResponse{
-			ID:               helpdesk.ID,
-			Subject:          helpdesk.Subject,
-			Priority:         helpdesk.Priority,
-			DepartmentID:     helpdesk.DepartmentID,
-			DepartmentName:   helpdesk.DepartmentName,
-			EmployeeID:       helpdesk.EmployeeID,
-			EmployeeUsername: helpdesk.EmployeeUsername,
-			EmployeeFullName: helpdesk.EmployeeFullName,
-			Description:      helpdesk.Description,
-			Status:           helpdesk.Status,
-			CreatedAt:        helpdesk.CreatedAt,
-			UpdatedAt:        helpdesk.UpdatedAt,
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
 		}
 
 		successResponse := map[string]interface{}{
@@ -822,6 +874,7 @@ func DeleteHelpdeskByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			return c.JSON(http.StatusForbidden, errorResponse)
 		}
 
+		deleteHelpdeskThread(db, helpdesk.ID)
 		db.Delete(&helpdesk)
 
 		successResponse := helper.Response{
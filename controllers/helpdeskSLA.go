@@ -0,0 +1,830 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHelpdeskSLA dipakai jika belum ada policy untuk prioritas tersebut (dalam menit: respons pertama, penyelesaian)
+var defaultHelpdeskSLA = map[string][2]int{
+	"Low":      {24 * 60, 5 * 24 * 60},
+	"Medium":   {8 * 60, 3 * 24 * 60},
+	"High":     {4 * 60, 24 * 60},
+	"Critical": {60, 8 * 60},
+}
+
+func isHelpdeskResolved(status string) bool {
+	return status == "Closed" || status == "Resolved"
+}
+
+type HelpdeskSLAPolicyRequest struct {
+	FirstResponseMinutes int `json:"first_response_minutes"`
+	ResolutionMinutes    int `json:"resolution_minutes"`
+}
+
+type HelpdeskAgentRequest struct {
+	AdminID      uint  `json:"admin_id"`
+	DepartmentID uint  `json:"department_id"`
+	IsActive     *bool `json:"is_active"`
+}
+
+type AssignHelpdeskRequest struct {
+	AdminID uint `json:"admin_id"` // Kosongkan untuk penugasan otomatis (round-robin)
+}
+
+// helpdeskSLAPolicy mengembalikan target SLA untuk prioritas tiket
+func helpdeskSLAPolicy(db *gorm.DB, priority string) (time.Duration, time.Duration) {
+	var policy models.HelpdeskSLAPolicy
+	if err := db.Where("priority = ?", priority).First(&policy).Error; err == nil {
+		return time.Duration(policy.FirstResponseMinutes) * time.Minute, time.Duration(policy.ResolutionMinutes) * time.Minute
+	}
+
+	minutes, ok := defaultHelpdeskSLA[priority]
+	if !ok {
+		minutes = defaultHelpdeskSLA["Medium"]
+	}
+	return time.Duration(minutes[0]) * time.Minute, time.Duration(minutes[1]) * time.Minute
+}
+
+// applyHelpdeskSLA menghitung batas waktu SLA dari waktu pembuatan tiket
+func applyHelpdeskSLA(db *gorm.DB, helpdesk *models.Helpdesk) {
+	createdAt := time.Now()
+	if helpdesk.CreatedAt != nil {
+		createdAt = *helpdesk.CreatedAt
+	}
+
+	firstResponse, resolution := helpdeskSLAPolicy(db, helpdesk.Priority)
+	firstResponseDueAt := createdAt.Add(firstResponse)
+	resolutionDueAt := createdAt.Add(resolution)
+	helpdesk.FirstResponseDueAt = &firstResponseDueAt
+	helpdesk.ResolutionDueAt = &resolutionDueAt
+
+	// Status breach dihapus jika batas baru (misalnya setelah prioritas diturunkan) belum terlewati
+	currentTime := time.Now()
+	if firstResponseDueAt.After(currentTime) {
+		helpdesk.FirstResponseBreached = false
+	}
+	if resolutionDueAt.After(currentTime) {
+		helpdesk.ResolutionBreached = false
+	}
+}
+
+// nextHelpdeskAgent memilih agent secara round-robin: agent departemen tiket lebih dulu, lalu agent umum
+func nextHelpdeskAgent(db *gorm.DB, departmentID uint) *models.HelpdeskAgent {
+	for _, id := range []uint{departmentID, 0} {
+		var agent models.HelpdeskAgent
+		err := db.Where("department_id = ? AND is_active = ?", id, true).
+			Order("last_assigned_at ASC NULLS FIRST").Order("id ASC").
+			First(&agent).Error
+		if err == nil {
+			return &agent
+		}
+	}
+	return nil
+}
+
+// assignHelpdesk menugaskan tiket kepada admin lalu mengirimkan email penugasan
+func assignHelpdesk(db *gorm.DB, helpdesk *models.Helpdesk, admin models.Admin) {
+	currentTime := time.Now()
+	helpdesk.AssignedAdminID = admin.ID
+	helpdesk.AssignedAdminName = admin.FirstName + " " + admin.LastName
+	helpdesk.AssignedAt = &currentTime
+
+	db.Model(&models.HelpdeskAgent{}).Where("admin_id = ?", admin.ID).Update("last_assigned_at", currentTime)
+
+	if admin.Email != "" {
+		resolutionDueAt := ""
+		if helpdesk.ResolutionDueAt != nil {
+			resolutionDueAt = helpdesk.ResolutionDueAt.Format("2006-01-02 15:04")
+		}
+		err := helper.SendHelpdeskAssignmentNotification(admin.Email, helpdesk.AssignedAdminName, helpdesk.Subject, helpdesk.Priority, helpdesk.EmployeeFullName, resolutionDueAt)
+		if err != nil {
+			fmt.Println("Failed to send helpdesk assignment notification:", err)
+		}
+	}
+}
+
+// autoAssignHelpdesk menugaskan tiket ke agent berikutnya, mengembalikan false jika tidak ada agent aktif
+func autoAssignHelpdesk(db *gorm.DB, helpdesk *models.Helpdesk) bool {
+	agent := nextHelpdeskAgent(db, helpdesk.DepartmentID)
+	if agent == nil {
+		return false
+	}
+
+	var admin models.Admin
+	if err := db.First(&admin, agent.AdminID).Error; err != nil {
+		return false
+	}
+
+	assignHelpdesk(db, helpdesk, admin)
+	return true
+}
+
+// initHelpdeskSLA dipanggil setelah tiket dibuat untuk mengisi batas SLA dan penugasan otomatis
+func initHelpdeskSLA(db *gorm.DB, helpdesk *models.Helpdesk) {
+	applyHelpdeskSLA(db, helpdesk)
+	if helpdesk.AssignedAdminID == 0 {
+		autoAssignHelpdesk(db, helpdesk)
+	}
+
+	if err := db.Save(helpdesk).Error; err != nil {
+		fmt.Println("Failed to save helpdesk SLA:", err)
+	}
+}
+
+// updateHelpdeskSLAState menyesuaikan SLA ketika prioritas atau status tiket berubah
+func updateHelpdeskSLAState(db *gorm.DB, helpdesk *models.Helpdesk, oldPriority, oldStatus string) {
+	if helpdesk.Priority != oldPriority && helpdesk.ResolvedAt == nil {
+		applyHelpdeskSLA(db, helpdesk)
+	}
+
+	if helpdesk.Status != oldStatus {
+		if isHelpdeskResolved(helpdesk.Status) && !isHelpdeskResolved(oldStatus) {
+			currentTime := time.Now()
+			helpdesk.ResolvedAt = &currentTime
+		} else if !isHelpdeskResolved(helpdesk.Status) {
+			// Tiket dibuka kembali
+			helpdesk.ResolvedAt = nil
+		}
+	}
+}
+
+// EscalateHelpdeskTickets dijalankan oleh scheduler untuk menandai dan mengeskalasi tiket yang melewati SLA
+func EscalateHelpdeskTickets(db *gorm.DB) {
+	currentTime := time.Now()
+
+	var helpdesks []models.Helpdesk
+	db.Where("status NOT IN ?", []string{"Closed", "Resolved"}).
+		Where("(first_responded_at IS NULL AND first_response_breached = ? AND first_response_due_at < ?) OR (resolution_breached = ? AND resolution_due_at < ?)", false, currentTime, false, currentTime).
+		Find(&helpdesks)
+
+	var hrAdmins []models.Admin
+	db.Where("is_admin_hr = ?", true).Find(&hrAdmins)
+
+	for _, helpdesk := range helpdesks {
+		breachType := ""
+		var dueAt *time.Time
+		if helpdesk.ResolutionDueAt != nil && !helpdesk.ResolutionBreached && helpdesk.ResolutionDueAt.Before(currentTime) {
+			helpdesk.ResolutionBreached = true
+			breachType = "Resolution"
+			dueAt = helpdesk.ResolutionDueAt
+		}
+		if helpdesk.FirstRespondedAt == nil && helpdesk.FirstResponseDueAt != nil && !helpdesk.FirstResponseBreached && helpdesk.FirstResponseDueAt.Before(currentTime) {
+			helpdesk.FirstResponseBreached = true
+			if breachType == "" {
+				breachType = "First Response"
+				dueAt = helpdesk.FirstResponseDueAt
+			}
+		}
+		if breachType == "" {
+			continue
+		}
+
+		helpdesk.EscalationLevel++
+		helpdesk.EscalatedAt = &currentTime
+
+		// Tiket yang belum ditangani siapa pun langsung dicoba ditugaskan
+		if helpdesk.AssignedAdminID == 0 {
+			autoAssignHelpdesk(db, &helpdesk)
+		}
+
+		if err := db.Save(&helpdesk).Error; err != nil {
+			fmt.Println("Failed to escalate helpdesk ticket:", err)
+			continue
+		}
+
+		assignedAdminName := helpdesk.AssignedAdminName
+		if assignedAdminName == "" {
+			assignedAdminName = "-"
+		}
+
+		// Level 1 dikirim ke admin yang ditugaskan, level berikutnya juga ke seluruh admin HR dan kepala departemen
+		recipients := map[string]string{}
+		if helpdesk.AssignedAdminID != 0 {
+			var assignedAdmin models.Admin
+			if err := db.First(&assignedAdmin, helpdesk.AssignedAdminID).Error; err == nil && assignedAdmin.Email != "" {
+				recipients[assignedAdmin.Email] = assignedAdmin.FirstName + " " + assignedAdmin.LastName
+			}
+		}
+		if helpdesk.AssignedAdminID == 0 || helpdesk.EscalationLevel > 1 {
+			for _, admin := range hrAdmins {
+				if admin.Email != "" {
+					recipients[admin.Email] = admin.FirstName + " " + admin.LastName
+				}
+			}
+
+			var department models.Department
+			if err := db.First(&department, helpdesk.DepartmentID).Error; err == nil && department.EmployeeID != 0 {
+				var head models.Employee
+				if err := db.First(&head, department.EmployeeID).Error; err == nil && head.Email != "" {
+					recipients[head.Email] = head.FirstName + " " + head.LastName
+				}
+			}
+		}
+
+		for email, name := range recipients {
+			err := helper.SendHelpdeskEscalationNotification(email, name, helpdesk.Subject, helpdesk.Priority, breachType, dueAt.Format("2006-01-02 15:04"), assignedAdminName, helpdesk.EscalationLevel)
+			if err != nil {
+				fmt.Println("Failed to send helpdesk escalation notification:", err)
+			}
+		}
+	}
+}
+
+// helpdeskSLAStats menghitung kepatuhan SLA untuk ringkasan tiket admin
+func helpdeskSLAStats(db *gorm.DB) map[string]interface{} {
+	var helpdesks []models.Helpdesk
+	db.Select("id", "status", "first_response_due_at", "resolution_due_at", "first_responded_at", "resolved_at", "first_response_breached", "resolution_breached", "escalation_level").
+		Where("resolution_due_at IS NOT NULL").
+		Find(&helpdesks)
+
+	currentTime := time.Now()
+	firstResponseMet, firstResponseBreached := 0, 0
+	resolutionMet, resolutionBreached := 0, 0
+	overdue, escalated := 0, 0
+
+	for _, helpdesk := range helpdesks {
+		if helpdesk.FirstRespondedAt != nil {
+			if helpdesk.FirstResponseDueAt == nil || !helpdesk.FirstRespondedAt.After(*helpdesk.FirstResponseDueAt) {
+				firstResponseMet++
+			} else {
+				firstResponseBreached++
+			}
+		} else if helpdesk.FirstResponseBreached {
+			firstResponseBreached++
+		}
+
+		if helpdesk.ResolvedAt != nil {
+			if !helpdesk.ResolvedAt.After(*helpdesk.ResolutionDueAt) {
+				resolutionMet++
+			} else {
+				resolutionBreached++
+			}
+		} else if helpdesk.ResolutionBreached || helpdesk.ResolutionDueAt.Before(currentTime) {
+			resolutionBreached++
+		}
+
+		if !isHelpdeskResolved(helpdesk.Status) && helpdesk.ResolutionDueAt.Before(currentTime) {
+			overdue++
+		}
+		if helpdesk.EscalationLevel > 0 {
+			escalated++
+		}
+	}
+
+	complianceRate := func(met, breached int) float64 {
+		if met+breached == 0 {
+			return 100
+		}
+		return math.Round(float64(met)/float64(met+breached)*10000) / 100
+	}
+
+	return map[string]interface{}{
+		"first_response": map[string]interface{}{
+			"met":             firstResponseMet,
+			"breached":        firstResponseBreached,
+			"compliance_rate": complianceRate(firstResponseMet, firstResponseBreached),
+		},
+		"resolution": map[string]interface{}{
+			"met":             resolutionMet,
+			"breached":        resolutionBreached,
+			"compliance_rate": complianceRate(resolutionMet, resolutionBreached),
+		},
+		"overdue_open_tickets": overdue,
+		"escalated_tickets":    escalated,
+	}
+}
+
+// GetHelpdeskSLAPoliciesByAdmin menampilkan target SLA setiap prioritas, termasuk nilai default yang belum diubah
+func GetHelpdeskSLAPoliciesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		var policies []models.HelpdeskSLAPolicy
+		db.Find(&policies)
+
+		configured := map[string]models.HelpdeskSLAPolicy{}
+		for _, policy := range policies {
+			configured[policy.Priority] = policy
+		}
+
+		var response []models.HelpdeskSLAPolicy
+		for _, priority := range []string{"Low", "Medium", "High", "Critical"} {
+			if policy, ok := configured[priority]; ok {
+				response = append(response, policy)
+				delete(configured, priority)
+				continue
+			}
+			response = append(response, models.HelpdeskSLAPolicy{
+				Priority:             priority,
+				FirstResponseMinutes: defaultHelpdeskSLA[priority][0],
+				ResolutionMinutes:    defaultHelpdeskSLA[priority][1],
+			})
+		}
+		for _, policy := range configured {
+			response = append(response, policy)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Helpdesk SLA policies retrieved successfully",
+			"sla_policies": response,
+		})
+	}
+}
+
+// UpdateHelpdeskSLAPolicyByAdmin membuat atau memperbarui target SLA untuk satu prioritas
+func UpdateHelpdeskSLAPolicyByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		priority := c.Param("priority")
+		if _, ok := defaultHelpdeskSLA[priority]; !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid priority. Allowed values: Low, Medium, High, Critical"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request HelpdeskSLAPolicyRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.FirstResponseMinutes <= 0 || request.ResolutionMinutes <= 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "First response and resolution minutes must be greater than 0"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.FirstResponseMinutes > request.ResolutionMinutes {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "First response target cannot be longer than resolution target"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		var policy models.HelpdeskSLAPolicy
+		if err := db.Where("priority = ?", priority).First(&policy).Error; err != nil {
+			policy = models.HelpdeskSLAPolicy{Priority: priority, CreatedAt: &currentTime}
+		}
+		policy.FirstResponseMinutes = request.FirstResponseMinutes
+		policy.ResolutionMinutes = request.ResolutionMinutes
+		policy.UpdatedAt = currentTime
+
+		// Policy baru hanya berlaku untuk tiket yang dibuat setelah perubahan
+		if err := db.Save(&policy).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save SLA policy"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Helpdesk SLA policy saved successfully",
+			"sla_policy": policy,
+		})
+	}
+}
+
+func CreateHelpdeskAgentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		var request HelpdeskAgentRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var agentAdmin models.Admin
+		if err := db.First(&agentAdmin, request.AdminID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		departmentName := "All Departments"
+		if request.DepartmentID != 0 {
+			var department models.Department
+			if err := db.First(&department, request.DepartmentID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Department not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			departmentName = department.DepartmentName
+		}
+
+		var existingAgent models.HelpdeskAgent
+		if err := db.Where("admin_id = ? AND department_id = ?", request.AdminID, request.DepartmentID).First(&existingAgent).Error; err == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Admin is already an agent for this department"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		agent := models.HelpdeskAgent{
+			AdminID:        agentAdmin.ID,
+			AdminName:      agentAdmin.FirstName + " " + agentAdmin.LastName,
+			DepartmentID:   request.DepartmentID,
+			DepartmentName: departmentName,
+			IsActive:       request.IsActive == nil || *request.IsActive,
+			CreatedAt:      &currentTime,
+		}
+		if err := db.Create(&agent).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create helpdesk agent"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if !agent.IsActive {
+			// gorm mengabaikan nilai false saat create karena default:true
+			db.Model(&agent).Update("is_active", false)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Helpdesk agent created successfully",
+			"agent":   agent,
+		})
+	}
+}
+
+func GetAllHelpdeskAgentsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		query := db.Model(&models.HelpdeskAgent{})
+		if departmentID := c.QueryParam("department_id"); departmentID != "" {
+			query = query.Where("department_id = ?", departmentID)
+		}
+
+		var agents []models.HelpdeskAgent
+		query.Order("department_id ASC").Order("id ASC").Find(&agents)
+
+		// Jumlah tiket aktif per agent untuk melihat beban kerja
+		type workload struct {
+			AssignedAdminID uint
+			Count           int
+		}
+		var workloads []workload
+		db.Model(&models.Helpdesk{}).Select("assigned_admin_id, count(*) as count").
+			Where("assigned_admin_id <> 0 AND status NOT IN ?", []string{"Closed", "Resolved"}).
+			Group("assigned_admin_id").Scan(&workloads)
+
+		openTickets := map[uint]int{}
+		for _, w := range workloads {
+			openTickets[w.AssignedAdminID] = w.Count
+		}
+
+		var response []map[string]interface{}
+		for _, agent := range agents {
+			response = append(response, map[string]interface{}{
+				"agent":        agent,
+				"open_tickets": openTickets[agent.AdminID],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Helpdesk agents retrieved successfully",
+			"agents":  response,
+		})
+	}
+}
+
+func UpdateHelpdeskAgentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		var agent models.HelpdeskAgent
+		if err := db.First(&agent, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk agent not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request HelpdeskAgentRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.IsActive != nil {
+			agent.IsActive = *request.IsActive
+		}
+		agent.UpdatedAt = time.Now()
+
+		if err := db.Model(&agent).Updates(map[string]interface{}{"is_active": agent.IsActive, "updated_at": agent.UpdatedAt}).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update helpdesk agent"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Helpdesk agent updated successfully",
+			"agent":   agent,
+		})
+	}
+}
+
+func DeleteHelpdeskAgentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		var agent models.HelpdeskAgent
+		if err := db.First(&agent, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk agent not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Delete(&agent)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Helpdesk agent deleted successfully",
+		})
+	}
+}
+
+// AssignHelpdeskByAdmin menugaskan tiket ke admin tertentu, atau ke agent berikutnya jika admin_id kosong
+func AssignHelpdeskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+		helpdeskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid helpdesk ID format"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var helpdesk models.Helpdesk
+		if err := db.First(&helpdesk, uint(helpdeskID)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk data not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request AssignHelpdeskRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.AdminID != 0 {
+			var assignee models.Admin
+			if err := db.First(&assignee, request.AdminID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			assignHelpdesk(db, &helpdesk, assignee)
+		} else if !autoAssignHelpdesk(db, &helpdesk) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnprocessableEntity, Message: "No active helpdesk agent available for this department"}
+			return c.JSON(http.StatusUnprocessableEntity, errorResponse)
+		}
+
+		if helpdesk.ResolutionDueAt == nil {
+			applyHelpdeskSLA(db, &helpdesk)
+		}
+
+		if err := db.Save(&helpdesk).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to assign helpdesk"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		helpdeskResponse := HelpdeskResponse{
+			ID:                 helpdesk.ID,
+			Subject:            helpdesk.Subject,
+			Priority:           helpdesk.Priority,
+			DepartmentID:       helpdesk.DepartmentID,
+			DepartmentName:     helpdesk.DepartmentName,
+			EmployeeID:         helpdesk.EmployeeID,
+			EmployeeUsername:   helpdesk.EmployeeUsername,
+			EmployeeFullName:   helpdesk.EmployeeFullName,
+			EmployeeAvatarURL:  employeeAvatarURL(db, helpdesk.EmployeeID),
+			AssignedAdminID:    helpdesk.AssignedAdminID,
+			AssignedAdminName:  helpdesk.AssignedAdminName,
+			FirstResponseDueAt: helpdesk.FirstResponseDueAt,
+			ResolutionDueAt:    helpdesk.ResolutionDueAt,
+			EscalationLevel:    helpdesk.EscalationLevel,
+			Description:        helpdesk.Description,
+			Status:             helpdesk.Status,
+			CreatedAt:          helpdesk.CreatedAt,
+			UpdatedAt:          helpdesk.UpdatedAt,
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Helpdesk assigned successfully",
+			"helpdesk": helpdeskResponse,
+		})
+	}
+}
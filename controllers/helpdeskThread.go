@@ -0,0 +1,471 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"html"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxHelpdeskAttachmentSize  = 5 * 1024 * 1024
+	maxHelpdeskAttachmentCount = 5
+)
+
+// HelpdeskCommentRequest dapat dikirim sebagai JSON atau multipart form (jika ada lampiran)
+type HelpdeskCommentRequest struct {
+	Message    string `json:"message" form:"message"`
+	IsInternal bool   `json:"is_internal" form:"is_internal"`
+	Status     string `json:"status" form:"status"`
+}
+
+// recordHelpdeskStatusChange menyimpan riwayat perubahan status tiket
+func recordHelpdeskStatusChange(db *gorm.DB, helpdeskID uint, oldStatus, newStatus, changedByRole string, changedByID uint, changedByName string) {
+	if oldStatus == newStatus {
+		return
+	}
+
+	currentTime := time.Now()
+	history := models.HelpdeskStatusHistory{
+		HelpdeskID:    helpdeskID,
+		OldStatus:     oldStatus,
+		NewStatus:     newStatus,
+		ChangedByRole: changedByRole,
+		ChangedByID:   changedByID,
+		ChangedByName: changedByName,
+		CreatedAt:     &currentTime,
+	}
+	if err := db.Create(&history).Error; err != nil {
+		fmt.Println("Failed to record helpdesk status history:", err)
+	}
+}
+
+// createHelpdeskComment membaca pesan beserta lampiran dari request lalu menyimpannya pada tiket
+func createHelpdeskComment(c echo.Context, db *gorm.DB, helpdesk models.Helpdesk, request HelpdeskCommentRequest, authorRole string, authorID uint, authorName, avatarURL string) (models.HelpdeskComment, int, string) {
+	var comment models.HelpdeskComment
+
+	request.Message = strings.TrimSpace(request.Message)
+	if len(request.Message) < 1 || len(request.Message) > 3000 {
+		return comment, http.StatusBadRequest, "Comment message must be between 1 and 3000 characters"
+	}
+
+	var files []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		files = form.File["attachments"]
+	}
+	if len(files) > maxHelpdeskAttachmentCount {
+		return comment, http.StatusBadRequest, fmt.Sprintf("A comment can have at most %d attachments", maxHelpdeskAttachmentCount)
+	}
+
+	type uploadedFile struct {
+		file        *multipart.FileHeader
+		data        []byte
+		contentType string
+	}
+	var uploads []uploadedFile
+	for _, file := range files {
+		if helper.IsFileSizeExceeds(file, maxHelpdeskAttachmentSize) {
+			return comment, http.StatusBadRequest, "Each attachment must not exceed 5MB"
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return comment, http.StatusInternalServerError, "Failed to open attachment"
+		}
+		fileData, err := io.ReadAll(src)
+		src.Close()
+		if err != nil {
+			return comment, http.StatusInternalServerError, "Failed to read attachment"
+		}
+
+		contentType := helper.DetectContentType(fileData, file.Filename)
+		if contentType != "application/pdf" && !strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "text/plain") {
+			return comment, http.StatusBadRequest, "Attachment must be a PDF, image or text file"
+		}
+		uploads = append(uploads, uploadedFile{file: file, data: fileData, contentType: contentType})
+	}
+
+	currentTime := time.Now()
+	comment = models.HelpdeskComment{
+		HelpdeskID: helpdesk.ID,
+		AuthorRole: authorRole,
+		AuthorID:   authorID,
+		AuthorName: authorName,
+		AvatarURL:  avatarURL,
+		Message:    request.Message,
+		IsInternal: authorRole == "Admin" && request.IsInternal,
+		CreatedAt:  &currentTime,
+	}
+	if err := db.Create(&comment).Error; err != nil {
+		return comment, http.StatusInternalServerError, "Failed to create comment"
+	}
+
+	if len(uploads) > 0 {
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			db.Delete(&comment)
+			return comment, http.StatusInternalServerError, "Failed to initialize file storage"
+		}
+
+		for i, upload := range uploads {
+			storageKey := fmt.Sprintf("helpdesk_attachments/%d/%d/%d%s", helpdesk.ID, comment.ID, i+1, filepath.Ext(upload.file.Filename))
+			if err := fileStorage.Upload(storageKey, upload.data, upload.contentType); err != nil {
+				deleteHelpdeskComments(db, fileStorage, db.Where("id = ?", comment.ID))
+				return comment, http.StatusInternalServerError, "Failed to upload attachment"
+			}
+
+			attachment := models.HelpdeskAttachment{
+				HelpdeskID:  helpdesk.ID,
+				CommentID:   comment.ID,
+				FileName:    upload.file.Filename,
+				StorageKey:  storageKey,
+				ContentType: upload.contentType,
+				FileSize:    upload.file.Size,
+				CreatedAt:   &currentTime,
+			}
+			db.Create(&attachment)
+			comment.Attachments = append(comment.Attachments, attachment)
+		}
+	}
+
+	// Setiap aktivitas balasan ikut memperbarui waktu update tiket, balasan publik admin pertama menjadi respons pertama SLA
+	updates := map[string]interface{}{"updated_at": currentTime}
+	if authorRole == "Admin" && !comment.IsInternal && helpdesk.FirstRespondedAt == nil {
+		updates["first_responded_at"] = currentTime
+	}
+	db.Model(&helpdesk).Updates(updates)
+
+	return comment, http.StatusCreated, ""
+}
+
+// deleteHelpdeskComments menghapus komentar yang cocok dengan query beserta file lampirannya
+func deleteHelpdeskComments(db *gorm.DB, fileStorage helper.FileStorage, query *gorm.DB) {
+	var comments []models.HelpdeskComment
+	query.Preload("Attachments").Find(&comments)
+
+	for _, comment := range comments {
+		for _, attachment := range comment.Attachments {
+			if fileStorage != nil {
+				if err := fileStorage.Delete(attachment.StorageKey); err != nil {
+					fmt.Println("Failed to delete helpdesk attachment:", err)
+				}
+			}
+			db.Delete(&attachment)
+		}
+		db.Delete(&comment)
+	}
+}
+
+// deleteHelpdeskThread dipanggil saat tiket dihapus agar komentar, lampiran dan riwayat status ikut terhapus
+func deleteHelpdeskThread(db *gorm.DB, helpdeskID uint) {
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		fmt.Println("Failed to initialize file storage:", err)
+		fileStorage = nil
+	}
+	deleteHelpdeskComments(db, fileStorage, db.Where("helpdesk_id = ?", helpdeskID))
+	db.Where("helpdesk_id = ?", helpdeskID).Delete(&models.HelpdeskStatusHistory{})
+}
+
+// notifyHelpdeskReply mengirim email ke pihak lain: balasan admin ke karyawan, balasan karyawan ke admin HR
+func notifyHelpdeskReply(db *gorm.DB, helpdesk models.Helpdesk, comment models.HelpdeskComment) {
+	if comment.IsInternal {
+		return
+	}
+
+	message := html.EscapeString(comment.Message)
+	subject := html.EscapeString(helpdesk.Subject)
+
+	if comment.AuthorRole == "Admin" {
+		var employee models.Employee
+		if err := db.First(&employee, helpdesk.EmployeeID).Error; err != nil || employee.Email == "" {
+			return
+		}
+		if err := helper.SendHelpdeskReplyNotification(employee.Email, helpdesk.EmployeeFullName, subject, comment.AuthorName, message, helpdesk.Status); err != nil {
+			fmt.Println("Failed to send helpdesk reply notification:", err)
+		}
+		return
+	}
+
+	var admins []models.Admin
+	db.Where("is_admin_hr = ?", true).Find(&admins)
+	for _, admin := range admins {
+		if admin.Email == "" {
+			continue
+		}
+		if err := helper.SendHelpdeskReplyNotification(admin.Email, admin.FirstName+" "+admin.LastName, subject, comment.AuthorName, message, helpdesk.Status); err != nil {
+			fmt.Println("Failed to send helpdesk reply notification:", err)
+		}
+	}
+}
+
+// streamHelpdeskAttachment mengirimkan file lampiran ke client
+func streamHelpdeskAttachment(c echo.Context, attachment models.HelpdeskAttachment) error {
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	reader, err := fileStorage.Open(attachment.StorageKey)
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attachment file not found"}
+		return c.JSON(http.StatusNotFound, errorResponse)
+	}
+	defer reader.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	return c.Stream(http.StatusOK, attachment.ContentType, reader)
+}
+
+// CreateHelpdeskCommentByAdmin menambahkan balasan atau catatan internal, sekaligus dapat mengubah status tiket
+func CreateHelpdeskCommentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		helpdeskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid helpdesk ID format"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var helpdesk models.Helpdesk
+		if err := db.First(&helpdesk, uint(helpdeskID)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk data not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request HelpdeskCommentRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		authorName := adminUser.FirstName + " " + adminUser.LastName
+		comment, status, message := createHelpdeskComment(c, db, helpdesk, request, "Admin", adminUser.ID, authorName, "")
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		// Status hanya diubah lewat balasan publik, catatan internal tidak mengubah status tiket
+		if request.Status != "" && !comment.IsInternal && request.Status != helpdesk.Status {
+			oldStatus := helpdesk.Status
+			helpdesk.Status = request.Status
+			updateHelpdeskSLAState(db, &helpdesk, helpdesk.Priority, oldStatus)
+			db.Model(&helpdesk).Updates(map[string]interface{}{"status": helpdesk.Status, "resolved_at": helpdesk.ResolvedAt})
+			recordHelpdeskStatusChange(db, helpdesk.ID, oldStatus, helpdesk.Status, "Admin", adminUser.ID, authorName)
+		}
+
+		notifyHelpdeskReply(db, helpdesk, comment)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Comment created successfully",
+			"comment": comment,
+			"status":  helpdesk.Status,
+		})
+	}
+}
+
+// GetHelpdeskCommentsByAdmin menampilkan seluruh percakapan tiket termasuk catatan internal
+func GetHelpdeskCommentsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		helpdeskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid helpdesk ID format"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var helpdesk models.Helpdesk
+		if err := db.First(&helpdesk, uint(helpdeskID)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk data not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var comments []models.HelpdeskComment
+		db.Preload("Attachments").Where("helpdesk_id = ?", helpdesk.ID).Order("id ASC").Find(&comments)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Helpdesk comments retrieved successfully",
+			"comments": comments,
+		})
+	}
+}
+
+// GetHelpdeskStatusHistoryByAdmin menampilkan riwayat perubahan status tiket
+func GetHelpdeskStatusHistoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		helpdeskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid helpdesk ID format"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var helpdesk models.Helpdesk
+		if err := db.First(&helpdesk, uint(helpdeskID)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Helpdesk data not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var history []models.HelpdeskStatusHistory
+		db.Where("helpdesk_id = ?", helpdesk.ID).Order("id ASC").Find(&history)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"message":        "Helpdesk status history retrieved successfully",
+			"status_history": history,
+		})
+	}
+}
+
+func DownloadHelpdeskAttachmentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var attachment models.HelpdeskAttachment
+		if err := db.Where("id = ? AND helpdesk_id = ?", c.Param("attachment_id"), c.Param("id")).First(&attachment).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attachment not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamHelpdeskAttachment(c, attachment)
+	}
+}
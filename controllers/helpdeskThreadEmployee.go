@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// employeeHelpdesk mengambil tiket dari parameter URL dan memastikan tiket milik karyawan yang login
+func employeeHelpdesk(c echo.Context, db *gorm.DB, employee models.Employee) (models.Helpdesk, int, string) {
+	var helpdesk models.Helpdesk
+
+	helpdeskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return helpdesk, http.StatusBadRequest, "Invalid helpdesk ID format"
+	}
+
+	if err := db.First(&helpdesk, uint(helpdeskID)).Error; err != nil {
+		return helpdesk, http.StatusNotFound, "Helpdesk data not found"
+	}
+
+	if helpdesk.EmployeeID != employee.ID {
+		return helpdesk, http.StatusForbidden, "Helpdesk does not belong to the employee"
+	}
+
+	return helpdesk, http.StatusOK, ""
+}
+
+func CreateHelpdeskCommentByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		helpdesk, status, message := employeeHelpdesk(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		var request HelpdeskCommentRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Karyawan tidak dapat membuat catatan internal maupun mengubah status lewat balasan
+		request.IsInternal = false
+		comment, status, message := createHelpdeskComment(c, db, helpdesk, request, "Employee", employee.ID, employee.FirstName+" "+employee.LastName, employee.AvatarURL)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		notifyHelpdeskReply(db, helpdesk, comment)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Comment created successfully",
+			"comment": comment,
+		})
+	}
+}
+
+// GetHelpdeskCommentsByEmployee menampilkan percakapan tiket tanpa catatan internal admin
+func GetHelpdeskCommentsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		helpdesk, status, message := employeeHelpdesk(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		var comments []models.HelpdeskComment
+		db.Preload("Attachments").Where("helpdesk_id = ? AND is_internal = ?", helpdesk.ID, false).Order("id ASC").Find(&comments)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Helpdesk comments retrieved successfully",
+			"comments": comments,
+		})
+	}
+}
+
+func GetHelpdeskStatusHistoryByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		helpdesk, status, message := employeeHelpdesk(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		var history []models.HelpdeskStatusHistory
+		db.Where("helpdesk_id = ?", helpdesk.ID).Order("id ASC").Find(&history)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"message":        "Helpdesk status history retrieved successfully",
+			"status_history": history,
+		})
+	}
+}
+
+func DownloadHelpdeskAttachmentByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		helpdesk, status, message := employeeHelpdesk(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		// Lampiran pada catatan internal tidak boleh diunduh oleh karyawan
+		var attachment models.HelpdeskAttachment
+		err = db.Joins("JOIN helpdesk_comments ON helpdesk_comments.id = helpdesk_attachments.comment_id").
+			Where("helpdesk_attachments.id = ? AND helpdesk_attachments.helpdesk_id = ? AND helpdesk_comments.is_internal = ?", c.Param("attachment_id"), helpdesk.ID, false).
+			First(&attachment).Error
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Attachment not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamHelpdeskAttachment(c, attachment)
+	}
+}
@@ -0,0 +1,800 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const interviewScheduleLayout = "2006-01-02 15:04"
+
+var validInterviewRecommendations = map[string]bool{
+	"Strong Hire":    true,
+	"Hire":           true,
+	"No Hire":        true,
+	"Strong No Hire": true,
+}
+
+// interviewRecommendationScores dipakai untuk menghitung rekomendasi gabungan dari seluruh scorecard
+var interviewRecommendationScores = map[string]float64{
+	"Strong Hire":    2,
+	"Hire":           1,
+	"No Hire":        -1,
+	"Strong No Hire": -2,
+}
+
+type InterviewRequest struct {
+	CandidateID     uint   `json:"candidate_id"`
+	Title           string `json:"title"`
+	ScheduledAt     string `json:"scheduled_at"` // Format "2006-01-02 15:04" (WIB)
+	DurationMinutes int    `json:"duration_minutes"`
+	Location        string `json:"location"`
+	MeetingLink     string `json:"meeting_link"`
+	Notes           string `json:"notes"`
+	InterviewerIDs  []uint `json:"interviewer_ids"`
+}
+
+// parseInterviewSchedule membaca jadwal wawancara dalam zona waktu Asia/Jakarta
+func parseInterviewSchedule(value string) (time.Time, error) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(interviewScheduleLayout, value, loc)
+}
+
+func interviewScheduleText(interview models.Interview) string {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.Local
+	}
+	start := interview.ScheduledAt.In(loc)
+	end := start.Add(time.Duration(interview.DurationMinutes) * time.Minute)
+	return start.Format("Monday, 02 January 2006 15:04") + " - " + end.Format("15:04") + " WIB"
+}
+
+// loadInterviewers memastikan semua pewawancara adalah karyawan aktif yang memiliki email
+func loadInterviewers(db *gorm.DB, employeeIDs []uint) ([]models.Employee, error) {
+	uniqueIDs := []uint{}
+	seen := map[uint]bool{}
+	for _, id := range employeeIDs {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	if len(uniqueIDs) == 0 {
+		return nil, errors.New("At least one interviewer is required")
+	}
+
+	var employees []models.Employee
+	db.Where("id IN ? AND is_client = ? AND is_exit = ?", uniqueIDs, false, false).Find(&employees)
+	if len(employees) != len(uniqueIDs) {
+		return nil, errors.New("One or more interviewers are not active employees")
+	}
+
+	for _, employee := range employees {
+		if employee.Email == "" {
+			return nil, fmt.Errorf("Interviewer %s does not have an email address", employee.FullName)
+		}
+	}
+
+	return employees, nil
+}
+
+func interviewInvite(interview models.Interview, cancelled bool) helper.InterviewInvite {
+	location := interview.Location
+	if location == "" {
+		location = interview.MeetingLink
+	}
+
+	description := fmt.Sprintf("Wawancara %s untuk posisi %s.", interview.CandidateName, interview.JobTitle)
+	if interview.MeetingLink != "" {
+		description += "\nTautan meeting: " + interview.MeetingLink
+	}
+	if interview.Notes != "" {
+		description += "\n" + interview.Notes
+	}
+
+	attendees := []string{interview.CandidateEmail}
+	for _, interviewer := range interview.Interviewers {
+		attendees = append(attendees, interviewer.Email)
+	}
+
+	return helper.InterviewInvite{
+		UID:             fmt.Sprintf("interview-%d@hrharmony", interview.ID),
+		Summary:         interview.Title,
+		Description:     description,
+		Location:        location,
+		Start:           interview.ScheduledAt,
+		DurationMinutes: interview.DurationMinutes,
+		OrganizerEmail:  os.Getenv("SMTP_USERNAME"),
+		Attendees:       attendees,
+		Sequence:        interview.Sequence,
+		Cancelled:       cancelled,
+	}
+}
+
+// sendInterviewInvites mengirim undangan .ics ke kandidat dan pewawancara yang diberikan
+func sendInterviewInvites(interview models.Interview, includeCandidate bool, interviewers []models.InterviewInterviewer, cancelled bool) {
+	icsContent := helper.BuildInterviewICS(interviewInvite(interview, cancelled))
+	schedule := interviewScheduleText(interview)
+
+	if includeCandidate && interview.CandidateEmail != "" {
+		err := helper.SendInterviewInvitation(interview.CandidateEmail, interview.CandidateName, interview.Title, interview.JobTitle, schedule, interview.Location, interview.MeetingLink, cancelled, icsContent)
+		if err != nil {
+			fmt.Println("Failed to send interview invitation to candidate:", err)
+		}
+	}
+
+	for _, interviewer := range interviewers {
+		err := helper.SendInterviewInvitation(interviewer.Email, interviewer.FullName, interview.Title, interview.JobTitle, schedule, interview.Location, interview.MeetingLink, cancelled, icsContent)
+		if err != nil {
+			fmt.Println("Failed to send interview invitation to interviewer:", err)
+		}
+	}
+}
+
+// moveCandidateToInterviewStage memindahkan kandidat yang masih di tahap screening ke tahap wawancara pertama
+func moveCandidateToInterviewStage(db *gorm.DB, candidate *models.Candidate, adminUser models.Admin) {
+	if candidate.StageType != "Screening" {
+		return
+	}
+
+	var stage models.RecruitmentStage
+	if err := db.Where("stage_type = ?", "Interview").Order("sort_order ASC").First(&stage).Error; err != nil {
+		return
+	}
+
+	// Kandidat sudah menerima undangan wawancara, jadi email perpindahan tahap tidak dikirim lagi
+	if err := transitionCandidate(db, candidate, stage, "Interview scheduled", "", false, adminUser); err != nil {
+		fmt.Println("Failed to move candidate to interview stage:", err)
+	}
+}
+
+// candidateFeedbackSummary menggabungkan seluruh scorecard kandidat menjadi rekomendasi hire/no-hire
+func candidateFeedbackSummary(db *gorm.DB, candidateID uint) map[string]interface{} {
+	var scorecards []models.InterviewScorecard
+	db.Where("candidate_id = ?", candidateID).Order("submitted_at ASC").Find(&scorecards)
+
+	var pendingFeedback int64
+	db.Model(&models.InterviewInterviewer{}).
+		Joins("JOIN interviews ON interviews.id = interview_interviewers.interview_id").
+		Where("interviews.candidate_id = ? AND interviews.status <> ? AND interview_interviewers.feedback_submitted = ?", candidateID, "Cancelled", false).
+		Count(&pendingFeedback)
+
+	recommendationCounts := map[string]int{}
+	for recommendation := range validInterviewRecommendations {
+		recommendationCounts[recommendation] = 0
+	}
+
+	var technicalSkills, communication, problemSolving, cultureFit, experienceRelevance, overallRating, recommendationScore float64
+	for _, scorecard := range scorecards {
+		technicalSkills += float64(scorecard.TechnicalSkills)
+		communication += float64(scorecard.Communication)
+		problemSolving += float64(scorecard.ProblemSolving)
+		cultureFit += float64(scorecard.CultureFit)
+		experienceRelevance += float64(scorecard.ExperienceRelevance)
+		overallRating += scorecard.OverallRating
+		recommendationScore += interviewRecommendationScores[scorecard.Recommendation]
+		recommendationCounts[scorecard.Recommendation]++
+	}
+
+	recommendation := "Pending"
+	averages := map[string]float64{}
+	averageScore := 0.0
+	if count := float64(len(scorecards)); count > 0 {
+		averages["technical_skills"] = math.Round(technicalSkills/count*100) / 100
+		averages["communication"] = math.Round(communication/count*100) / 100
+		averages["problem_solving"] = math.Round(problemSolving/count*100) / 100
+		averages["culture_fit"] = math.Round(cultureFit/count*100) / 100
+		averages["experience_relevance"] = math.Round(experienceRelevance/count*100) / 100
+		averages["overall_rating"] = math.Round(overallRating/count*100) / 100
+		averageScore = math.Round(recommendationScore/count*100) / 100
+
+		// Nilai seimbang dianggap No Hire, keputusan hire harus didukung mayoritas pewawancara
+		switch {
+		case averageScore >= 1.5:
+			recommendation = "Strong Hire"
+		case averageScore > 0:
+			recommendation = "Hire"
+		case averageScore <= -1.5:
+			recommendation = "Strong No Hire"
+		default:
+			recommendation = "No Hire"
+		}
+	}
+
+	return map[string]interface{}{
+		"recommendation":        recommendation,
+		"recommendation_score":  averageScore,
+		"scorecard_count":       len(scorecards),
+		"pending_feedback":      pendingFeedback,
+		"average_ratings":       averages,
+		"recommendation_counts": recommendationCounts,
+		"scorecards":            scorecards,
+	}
+}
+
+// cancelUpcomingInterviews membatalkan wawancara yang belum berlangsung saat kandidat keluar dari pipeline
+func cancelUpcomingInterviews(db *gorm.DB, candidateID uint) {
+	var interviews []models.Interview
+	db.Preload("Interviewers").Where("candidate_id = ? AND status = ? AND scheduled_at > ?", candidateID, "Scheduled", time.Now()).Find(&interviews)
+
+	for _, interview := range interviews {
+		interview.Status = "Cancelled"
+		interview.Sequence++
+		interview.UpdatedAt = time.Now()
+		if err := db.Omit("Interviewers").Save(&interview).Error; err != nil {
+			fmt.Println("Failed to cancel interview:", err)
+			continue
+		}
+		sendInterviewInvites(interview, false, interview.Interviewers, true)
+	}
+}
+
+// deleteCandidateInterviews menghapus seluruh wawancara dan scorecard milik kandidat
+func deleteCandidateInterviews(db *gorm.DB, candidateID uint) {
+	var interviewIDs []uint
+	db.Model(&models.Interview{}).Where("candidate_id = ?", candidateID).Pluck("id", &interviewIDs)
+	if len(interviewIDs) == 0 {
+		return
+	}
+
+	db.Where("interview_id IN ?", interviewIDs).Delete(&models.InterviewScorecard{})
+	db.Where("interview_id IN ?", interviewIDs).Delete(&models.InterviewInterviewer{})
+	db.Where("id IN ?", interviewIDs).Delete(&models.Interview{})
+}
+
+func ScheduleInterviewByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request InterviewRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, request.CandidateID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if candidate.Status != "Active" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interviews can only be scheduled for active candidates"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		scheduledAt, err := parseInterviewSchedule(request.ScheduledAt)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid scheduled_at format. Required format: YYYY-MM-DD HH:MM"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if scheduledAt.Before(time.Now()) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interview must be scheduled in the future"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.DurationMinutes <= 0 {
+			request.DurationMinutes = 60
+		}
+		if request.DurationMinutes > 480 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interview duration must not exceed 480 minutes"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Location == "" && request.MeetingLink == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Location or meeting link is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		employees, err := loadInterviewers(db, request.InterviewerIDs)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var job models.NewJob
+		db.First(&job, candidate.NewJobID)
+
+		title := strings.TrimSpace(request.Title)
+		if title == "" {
+			title = "Interview: " + strings.TrimSpace(candidate.FirstName+" "+candidate.LastName) + " - " + candidate.JobTitle
+		}
+
+		currentTime := time.Now()
+		interview := models.Interview{
+			CandidateID:            candidate.ID,
+			CandidateName:          strings.TrimSpace(candidate.FirstName + " " + candidate.LastName),
+			CandidateEmail:         candidate.Email,
+			NewJobID:               candidate.NewJobID,
+			JobTitle:               candidate.JobTitle,
+			DesignationID:          job.DesignationID,
+			DesignationName:        job.DesignationName,
+			Title:                  title,
+			ScheduledAt:            scheduledAt,
+			DurationMinutes:        request.DurationMinutes,
+			Location:               request.Location,
+			MeetingLink:            request.MeetingLink,
+			Notes:                  request.Notes,
+			Status:                 "Scheduled",
+			CreatedByAdminID:       adminUser.ID,
+			CreatedByAdminUsername: adminUser.Username,
+			CreatedAt:              &currentTime,
+		}
+		for _, employee := range employees {
+			interview.Interviewers = append(interview.Interviewers, models.InterviewInterviewer{
+				EmployeeID:  employee.ID,
+				FullName:    employee.FullName,
+				Email:       employee.Email,
+				Designation: employee.Designation,
+			})
+		}
+
+		if err := db.Create(&interview).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to schedule interview"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		moveCandidateToInterviewStage(db, &candidate, adminUser)
+		sendInterviewInvites(interview, true, interview.Interviewers, false)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":      http.StatusCreated,
+			"error":     false,
+			"message":   "Interview scheduled successfully",
+			"interview": interview,
+		})
+	}
+}
+
+func GetAllInterviewsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.Interview{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("candidate_name ILIKE ? OR job_title ILIKE ? OR title ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+		if candidateID := c.QueryParam("candidate_id"); candidateID != "" {
+			query = query.Where("candidate_id = ?", candidateID)
+		}
+		if jobID := c.QueryParam("job_id"); jobID != "" {
+			query = query.Where("new_job_id = ?", jobID)
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+		if startDate := c.QueryParam("start_date"); startDate != "" {
+			query = query.Where("scheduled_at >= ?", startDate)
+		}
+		if endDate := c.QueryParam("end_date"); endDate != "" {
+			query = query.Where("scheduled_at < (?::date + 1)", endDate)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var interviews []models.Interview
+		if err := query.Preload("Interviewers").Order("scheduled_at DESC").Offset(offset).Limit(perPage).Find(&interviews).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching interviews"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Interviews retrieved successfully",
+			"interviews": interviews,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetInterviewByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var interview models.Interview
+		if err := db.Preload("Interviewers").First(&interview, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Interview not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var scorecards []models.InterviewScorecard
+		db.Where("interview_id = ?", interview.ID).Order("submitted_at ASC").Find(&scorecards)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Interview retrieved successfully",
+			"interview":  interview,
+			"scorecards": scorecards,
+		})
+	}
+}
+
+// UpdateInterviewByAdmin menjadwalkan ulang wawancara dan/atau mengubah pewawancara, undangan dikirim ulang
+func UpdateInterviewByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var interview models.Interview
+		if err := db.Preload("Interviewers").First(&interview, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Interview not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if interview.Status != "Scheduled" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only scheduled interviews can be updated"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request InterviewRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.ScheduledAt != "" {
+			scheduledAt, err := parseInterviewSchedule(request.ScheduledAt)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid scheduled_at format. Required format: YYYY-MM-DD HH:MM"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if scheduledAt.Before(time.Now()) {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interview must be scheduled in the future"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			interview.ScheduledAt = scheduledAt
+		}
+		if request.DurationMinutes > 0 {
+			if request.DurationMinutes > 480 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interview duration must not exceed 480 minutes"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			interview.DurationMinutes = request.DurationMinutes
+		}
+		if title := strings.TrimSpace(request.Title); title != "" {
+			interview.Title = title
+		}
+		if request.Location != "" {
+			interview.Location = request.Location
+		}
+		if request.MeetingLink != "" {
+			interview.MeetingLink = request.MeetingLink
+		}
+		if request.Notes != "" {
+			interview.Notes = request.Notes
+		}
+
+		// Pewawancara yang dihapus menerima pembatalan, pewawancara lama tetap menerima jadwal terbaru
+		var removedInterviewers []models.InterviewInterviewer
+		if len(request.InterviewerIDs) > 0 {
+			employees, err := loadInterviewers(db, request.InterviewerIDs)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			existing := map[uint]models.InterviewInterviewer{}
+			for _, interviewer := range interview.Interviewers {
+				existing[interviewer.EmployeeID] = interviewer
+			}
+
+			var interviewers []models.InterviewInterviewer
+			for _, employee := range employees {
+				if interviewer, ok := existing[employee.ID]; ok {
+					interviewers = append(interviewers, interviewer)
+					delete(existing, employee.ID)
+					continue
+				}
+				interviewers = append(interviewers, models.InterviewInterviewer{
+					InterviewID: interview.ID,
+					EmployeeID:  employee.ID,
+					FullName:    employee.FullName,
+					Email:       employee.Email,
+					Designation: employee.Designation,
+				})
+			}
+			for _, interviewer := range existing {
+				if interviewer.FeedbackSubmitted {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot remove an interviewer who has already submitted a scorecard"}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+				removedInterviewers = append(removedInterviewers, interviewer)
+			}
+			interview.Interviewers = interviewers
+		}
+
+		interview.Sequence++
+		interview.UpdatedAt = time.Now()
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, interviewer := range removedInterviewers {
+				if err := tx.Delete(&interviewer).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(&interview).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update interview"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		sendInterviewInvites(interview, true, interview.Interviewers, false)
+		sendInterviewInvites(interview, false, removedInterviewers, true)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Interview updated successfully",
+			"interview": interview,
+		})
+	}
+}
+
+func CancelInterviewByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var interview models.Interview
+		if err := db.Preload("Interviewers").First(&interview, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Interview not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if interview.Status != "Scheduled" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only scheduled interviews can be cancelled"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		interview.Status = "Cancelled"
+		interview.Sequence++
+		interview.UpdatedAt = time.Now()
+		if err := db.Omit("Interviewers").Save(&interview).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to cancel interview"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		sendInterviewInvites(interview, true, interview.Interviewers, true)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Interview cancelled successfully",
+			"interview": interview,
+		})
+	}
+}
+
+// GetCandidateFeedbackByAdmin menampilkan gabungan scorecard wawancara dan rekomendasi hire/no-hire kandidat
+func GetCandidateFeedbackByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Candidate feedback retrieved successfully",
+			"feedback": candidateFeedbackSummary(db, candidate.ID),
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type InterviewScorecardRequest struct {
+	TechnicalSkills     int    `json:"technical_skills"`
+	Communication       int    `json:"communication"`
+	ProblemSolving      int    `json:"problem_solving"`
+	CultureFit          int    `json:"culture_fit"`
+	ExperienceRelevance int    `json:"experience_relevance"`
+	Recommendation      string `json:"recommendation"`
+	Strengths           string `json:"strengths"`
+	Concerns            string `json:"concerns"`
+	Comments            string `json:"comments"`
+}
+
+// employeeInterview mengambil wawancara dari parameter URL dan memastikan karyawan adalah pewawancaranya
+func employeeInterview(c echo.Context, db *gorm.DB, employee models.Employee) (models.Interview, models.InterviewInterviewer, int, string) {
+	var interview models.Interview
+	var interviewer models.InterviewInterviewer
+
+	interviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return interview, interviewer, http.StatusBadRequest, "Invalid interview ID format"
+	}
+
+	if err := db.Preload("Interviewers").First(&interview, uint(interviewID)).Error; err != nil {
+		return interview, interviewer, http.StatusNotFound, "Interview not found"
+	}
+
+	for _, item := range interview.Interviewers {
+		if item.EmployeeID == employee.ID {
+			return interview, item, http.StatusOK, ""
+		}
+	}
+
+	return interview, interviewer, http.StatusForbidden, "You are not an interviewer for this interview"
+}
+
+// GetInterviewsByEmployee menampilkan wawancara di mana karyawan yang login menjadi pewawancara
+func GetInterviewsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.Interview{}).
+			Joins("JOIN interview_interviewers ON interview_interviewers.interview_id = interviews.id").
+			Where("interview_interviewers.employee_id = ?", employee.ID)
+
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("interviews.status = ?", status)
+		}
+		if c.QueryParam("upcoming") == "true" {
+			query = query.Where("interviews.scheduled_at >= ?", time.Now())
+		}
+		if c.QueryParam("pending_feedback") == "true" {
+			query = query.Where("interview_interviewers.feedback_submitted = ? AND interviews.status <> ?", false, "Cancelled")
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var interviews []models.Interview
+		if err := query.Preload("Interviewers").Order("interviews.scheduled_at DESC").Offset(offset).Limit(perPage).Find(&interviews).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching interviews"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var response []map[string]interface{}
+		for _, interview := range interviews {
+			feedbackSubmitted := false
+			for _, interviewer := range interview.Interviewers {
+				if interviewer.EmployeeID == employee.ID {
+					feedbackSubmitted = interviewer.FeedbackSubmitted
+				}
+			}
+			response = append(response, map[string]interface{}{
+				"interview":          interview,
+				"feedback_submitted": feedbackSubmitted,
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Interviews retrieved successfully",
+			"interviews": response,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+// GetInterviewByIDByEmployee menampilkan detail wawancara, profil kandidat, jabatan yang dilamar dan scorecard milik pewawancara
+func GetInterviewByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		interview, _, status, message := employeeInterview(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		var candidate models.Candidate
+		db.First(&candidate, interview.CandidateID)
+
+		var job models.NewJob
+		db.First(&job, interview.NewJobID)
+
+		var designation models.Designation
+		db.First(&designation, interview.DesignationID)
+
+		// Pewawancara hanya melihat scorecard miliknya agar penilaian tidak saling mempengaruhi
+		var scorecard *models.InterviewScorecard
+		var existingScorecard models.InterviewScorecard
+		if err := db.Where("interview_id = ? AND employee_id = ?", interview.ID, employee.ID).First(&existingScorecard).Error; err == nil {
+			scorecard = &existingScorecard
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Interview retrieved successfully",
+			"interview": interview,
+			"candidate": map[string]interface{}{
+				"id":             candidate.ID,
+				"first_name":     candidate.FirstName,
+				"last_name":      candidate.LastName,
+				"email":          candidate.Email,
+				"contact_number": candidate.ContactNumber,
+				"cover_letter":   candidate.CoverLetter,
+				"stage_name":     candidate.StageName,
+				"cv_file_name":   candidate.CVFileName,
+			},
+			"job": map[string]interface{}{
+				"id":                 job.ID,
+				"title":              job.Title,
+				"job_type":           job.JobType,
+				"minimum_experience": job.MinimumExperience,
+				"short_description":  job.ShortDescription,
+				"long_description":   job.LongDescription,
+			},
+			"designation": map[string]interface{}{
+				"id":               designation.ID,
+				"designation_name": designation.DesignationName,
+				"department_name":  designation.DepartmentName,
+				"description":      designation.Description,
+			},
+			"scorecard": scorecard,
+		})
+	}
+}
+
+func DownloadInterviewCandidateCVByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		interview, _, status, message := employeeInterview(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		var candidate models.Candidate
+		if err := db.First(&candidate, interview.CandidateID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		reader, err := fileStorage.Open(candidate.CVKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "CV file not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+		defer reader.Close()
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", candidate.CVFileName))
+		return c.Stream(http.StatusOK, candidate.CVContentType, reader)
+	}
+}
+
+// SubmitInterviewScorecardByEmployee menyimpan scorecard pewawancara, scorecard dapat diperbarui selama wawancara belum dibatalkan
+func SubmitInterviewScorecardByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		interview, interviewer, status, message := employeeInterview(c, db, employee)
+		if message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		if interview.Status == "Cancelled" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Interview has been cancelled"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if time.Now().Before(interview.ScheduledAt) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Scorecard can only be submitted after the interview has started"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request InterviewScorecardRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		ratings := []int{request.TechnicalSkills, request.Communication, request.ProblemSolving, request.CultureFit, request.ExperienceRelevance}
+		total := 0
+		for _, rating := range ratings {
+			if rating < 1 || rating > 5 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "All ratings are required and must be between 1 and 5"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			total += rating
+		}
+
+		if !validInterviewRecommendations[request.Recommendation] {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid recommendation. Allowed values: Strong Hire, Hire, No Hire, Strong No Hire"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(request.Strengths) > 2000 || len(request.Concerns) > 2000 || len(request.Comments) > 2000 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Feedback text must not exceed 2000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		var scorecard models.InterviewScorecard
+		isNew := db.Where("interview_id = ? AND employee_id = ?", interview.ID, employee.ID).First(&scorecard).Error != nil
+		if isNew {
+			scorecard = models.InterviewScorecard{
+				InterviewID: interview.ID,
+				EmployeeID:  employee.ID,
+				CandidateID: interview.CandidateID,
+				FullName:    interviewer.FullName,
+				SubmittedAt: &currentTime,
+			}
+		}
+
+		scorecard.TechnicalSkills = request.TechnicalSkills
+		scorecard.Communication = request.Communication
+		scorecard.ProblemSolving = request.ProblemSolving
+		scorecard.CultureFit = request.CultureFit
+		scorecard.ExperienceRelevance = request.ExperienceRelevance
+		scorecard.OverallRating = float64(total) / float64(len(ratings))
+		scorecard.Recommendation = request.Recommendation
+		scorecard.Strengths = request.Strengths
+		scorecard.Concerns = request.Concerns
+		scorecard.Comments = request.Comments
+		scorecard.UpdatedAt = currentTime
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&scorecard).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&interviewer).Update("feedback_submitted", true).Error; err != nil {
+				return err
+			}
+
+			// Wawancara selesai setelah semua pewawancara mengirimkan scorecard
+			var pending int64
+			tx.Model(&models.InterviewInterviewer{}).Where("interview_id = ? AND feedback_submitted = ?", interview.ID, false).Count(&pending)
+			if pending == 0 && interview.Status == "Scheduled" {
+				return tx.Model(&models.Interview{}).Where("id = ?", interview.ID).Updates(map[string]interface{}{"status": "Completed", "updated_at": currentTime}).Error
+			}
+			return nil
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to submit scorecard"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		statusCode := http.StatusOK
+		responseMessage := "Scorecard updated successfully"
+		if isNew {
+			statusCode = http.StatusCreated
+			responseMessage = "Scorecard submitted successfully"
+		}
+
+		return c.JSON(statusCode, map[string]interface{}{
+			"code":      statusCode,
+			"error":     false,
+			"message":   responseMessage,
+			"scorecard": scorecard,
+		})
+	}
+}
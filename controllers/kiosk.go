@@ -0,0 +1,868 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Masa berlaku satu QR token kiosk; token dari periode sebelumnya masih diterima
+const kioskQRTokenPeriod = 30 * time.Second
+
+var attendancePINPattern = regexp.MustCompile(`^[0-9]{4,6}$`)
+
+// Setelah kioskPINMaxAttempts kali PIN salah, username dikunci di kiosk tersebut selama kioskPINLockoutDuration
+const (
+	kioskPINMaxAttempts     = 5
+	kioskPINLockoutDuration = 15 * time.Minute
+)
+
+// generateKioskAPIKey membuat API key acak; hanya hash-nya yang disimpan di database
+func generateKioskAPIKey() (string, string, error) {
+	randomBytes := make([]byte, 24)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", "", err
+	}
+	apiKey := "kiosk_" + hex.EncodeToString(randomBytes)
+	return apiKey, hashKioskAPIKey(apiKey), nil
+}
+
+func hashKioskAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// authenticateKiosk memverifikasi header X-Kiosk-Key milik kiosk yang aktif
+func authenticateKiosk(db *gorm.DB, c echo.Context) (models.Kiosk, error) {
+	var kiosk models.Kiosk
+	apiKey := c.Request().Header.Get("X-Kiosk-Key")
+	if apiKey == "" {
+		return kiosk, errors.New("Kiosk API key is missing")
+	}
+
+	result := db.Where("api_key_hash = ?", hashKioskAPIKey(apiKey)).First(&kiosk)
+	if result.Error != nil {
+		return kiosk, errors.New("Invalid kiosk API key")
+	}
+
+	if !kiosk.IsActive {
+		return kiosk, errors.New("Kiosk is inactive")
+	}
+
+	currentTime := time.Now()
+	kiosk.LastSeenAt = &currentTime
+	db.Model(&kiosk).Update("last_seen_at", currentTime)
+
+	return kiosk, nil
+}
+
+func kioskQRSignature(kioskID uint, period int64, secretKey []byte) string {
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write([]byte(fmt.Sprintf("%d:%d", kioskID, period)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateKioskQRToken membuat token QR yang berganti setiap kioskQRTokenPeriod
+func generateKioskQRToken(kioskID uint, now time.Time, secretKey []byte) (string, time.Time) {
+	period := now.Unix() / int64(kioskQRTokenPeriod.Seconds())
+	payload := fmt.Sprintf("%d.%d.%s", kioskID, period, kioskQRSignature(kioskID, period, secretKey))
+	expiresAt := time.Unix((period+1)*int64(kioskQRTokenPeriod.Seconds()), 0)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)), expiresAt
+}
+
+// verifyKioskQRToken mengembalikan ID kiosk jika token valid dan belum kedaluwarsa
+func verifyKioskQRToken(token string, now time.Time, secretKey []byte) (uint, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("Invalid QR token")
+	}
+
+	parts := strings.Split(string(decoded), ".")
+	if len(parts) != 3 {
+		return 0, errors.New("Invalid QR token")
+	}
+
+	kioskID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, errors.New("Invalid QR token")
+	}
+	period, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, errors.New("Invalid QR token")
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(kioskQRSignature(uint(kioskID), period, secretKey))) {
+		return 0, errors.New("Invalid QR token")
+	}
+
+	currentPeriod := now.Unix() / int64(kioskQRTokenPeriod.Seconds())
+	if period != currentPeriod && period != currentPeriod-1 {
+		return 0, errors.New("QR token has expired")
+	}
+
+	return uint(kioskID), nil
+}
+
+func CreateKioskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var kiosk models.Kiosk
+		if err := c.Bind(&kiosk); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if kiosk.KioskName == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kiosk name is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		apiKey, apiKeyHash, err := generateKioskAPIKey()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate kiosk API key"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		currentTime := time.Now()
+		kiosk.APIKeyHash = apiKeyHash
+		kiosk.APIKeyPrefix = apiKey[:12]
+		kiosk.IsActive = true
+		kiosk.LastSeenAt = nil
+		kiosk.CreatedAt = &currentTime
+
+		db.Create(&kiosk)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Kiosk created successfully. Store the API key now, it will not be shown again",
+			"data":    kiosk,
+			"api_key": apiKey,
+		})
+	}
+}
+
+func GetAllKiosksByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.Kiosk{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("kiosk_name ILIKE ? OR location ILIKE ?", searchPattern, searchPattern)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var kiosks []models.Kiosk
+		if err := query.Order("id DESC").Offset(offset).Limit(perPage).Find(&kiosks).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch kiosks"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Kiosks retrieved successfully",
+			"data":    kiosks,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetKioskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var kiosk models.Kiosk
+		result = db.First(&kiosk, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Kiosk not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Kiosk retrieved successfully",
+			"data":    kiosk,
+		})
+	}
+}
+
+type UpdateKioskRequest struct {
+	KioskName string `json:"kiosk_name"`
+	Location  string `json:"location"`
+	IsActive  *bool  `json:"is_active"`
+}
+
+func UpdateKioskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var kiosk models.Kiosk
+		result = db.First(&kiosk, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Kiosk not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request UpdateKioskRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.KioskName != "" {
+			kiosk.KioskName = request.KioskName
+		}
+		if request.Location != "" {
+			kiosk.Location = request.Location
+		}
+		if request.IsActive != nil {
+			kiosk.IsActive = *request.IsActive
+		}
+
+		db.Save(&kiosk)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Kiosk updated successfully",
+			"data":    kiosk,
+		})
+	}
+}
+
+// RegenerateKioskAPIKeyByAdmin mengganti API key kiosk, key lama langsung tidak berlaku
+func RegenerateKioskAPIKeyByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var kiosk models.Kiosk
+		result = db.First(&kiosk, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Kiosk not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		apiKey, apiKeyHash, err := generateKioskAPIKey()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate kiosk API key"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		kiosk.APIKeyHash = apiKeyHash
+		kiosk.APIKeyPrefix = apiKey[:12]
+		db.Save(&kiosk)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Kiosk API key regenerated successfully. Store the API key now, it will not be shown again",
+			"data":    kiosk,
+			"api_key": apiKey,
+		})
+	}
+}
+
+func DeleteKioskByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var kiosk models.Kiosk
+		result = db.First(&kiosk, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Kiosk not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Delete(&kiosk)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Kiosk deleted successfully",
+		})
+	}
+}
+
+type AttendancePINRequest struct {
+	PIN string `json:"pin"`
+}
+
+// SetEmployeeAttendancePINByAdmin mengatur PIN kiosk untuk karyawan yang tidak memiliki ponsel
+func SetEmployeeAttendancePINByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request AttendancePINRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !attendancePINPattern.MatchString(request.PIN) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "PIN must be 4 to 6 digits"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		hashedPIN, err := bcrypt.GenerateFromPassword([]byte(request.PIN), bcrypt.DefaultCost)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to hash PIN"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		db.Model(&employee).Update("attendance_pin", string(hashedPIN))
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Employee attendance PIN updated successfully",
+		})
+	}
+}
+
+// GetKioskQRToken dipanggil perangkat kiosk untuk menampilkan QR code yang selalu berganti
+func GetKioskQRToken(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		kiosk, err := authenticateKiosk(db, c)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: err.Error()}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		token, expiresAt := generateKioskQRToken(kiosk.ID, time.Now(), secretKey)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "QR token generated successfully",
+			"token":      token,
+			"expires_at": expiresAt,
+			"kiosk_id":   kiosk.ID,
+			"kiosk_name": kiosk.KioskName,
+		})
+	}
+}
+
+type KioskPINCheckInRequest struct {
+	Username string `json:"username"`
+	PIN      string `json:"pin"`
+}
+
+// recordKioskPINFailure menambah hitungan PIN salah dan mengunci username jika batas tercapai
+func recordKioskPINFailure(db *gorm.DB, kioskID uint, username string) {
+	currentTime := time.Now()
+
+	var attempt models.KioskPINAttempt
+	if err := db.Where("kiosk_id = ? AND username = ?", kioskID, username).First(&attempt).Error; err != nil {
+		attempt = models.KioskPINAttempt{KioskID: kioskID, Username: username}
+	}
+
+	// Hitungan dimulai ulang jika kegagalan terakhir sudah lewat dari durasi penguncian
+	if attempt.LastFailedAt == nil || currentTime.Sub(*attempt.LastFailedAt) > kioskPINLockoutDuration {
+		attempt.FailedAttempts = 0
+	}
+	attempt.FailedAttempts++
+	attempt.LastFailedAt = &currentTime
+	if attempt.FailedAttempts >= kioskPINMaxAttempts {
+		lockedUntil := currentTime.Add(kioskPINLockoutDuration)
+		attempt.LockedUntil = &lockedUntil
+		attempt.FailedAttempts = 0
+	}
+	db.Save(&attempt)
+}
+
+// verifyKioskPIN memeriksa username dan PIN karyawan di kiosk dengan pembatasan percobaan.
+// Status HTTP dikembalikan bersama error.
+func verifyKioskPIN(db *gorm.DB, kioskID uint, request KioskPINCheckInRequest) (models.Employee, int, error) {
+	if request.Username == "" || request.PIN == "" {
+		return models.Employee{}, http.StatusBadRequest, errors.New("Username and PIN are required")
+	}
+
+	var attempt models.KioskPINAttempt
+	if err := db.Where("kiosk_id = ? AND username = ?", kioskID, request.Username).First(&attempt).Error; err == nil &&
+		attempt.LockedUntil != nil && time.Now().Before(*attempt.LockedUntil) {
+		return models.Employee{}, http.StatusTooManyRequests, fmt.Errorf("Too many failed PIN attempts. Try again after %s", attempt.LockedUntil.Format("15:04"))
+	}
+
+	var employee models.Employee
+	result := db.Where("username = ? AND is_client = ? AND is_exit = ?", request.Username, false, false).First(&employee)
+	if result.Error != nil || employee.AttendancePIN == "" ||
+		bcrypt.CompareHashAndPassword([]byte(employee.AttendancePIN), []byte(request.PIN)) != nil {
+		recordKioskPINFailure(db, kioskID, request.Username)
+		return models.Employee{}, http.StatusUnauthorized, errors.New("Invalid username or PIN")
+	}
+
+	if attempt.ID != 0 {
+		db.Delete(&attempt)
+	}
+	return employee, http.StatusOK, nil
+}
+
+// KioskPINCheckIn dipakai karyawan tanpa ponsel: username dan PIN dimasukkan langsung di kiosk
+func KioskPINCheckIn(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		kiosk, err := authenticateKiosk(db, c)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: err.Error()}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var request KioskPINCheckInRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		employee, statusCode, err := verifyKioskPIN(db, kiosk.ID, request)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		attendance, statusCode, err := checkInEmployee(db, employee, kiosk.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Employee check-in successful",
+			"full_name":  attendance.FullNameEmployee,
+			"time":       attendance.InTime,
+			"late":       attendance.Late,
+			"kiosk_name": kiosk.KioskName,
+		})
+	}
+}
+
+// KioskPINCheckOut adalah pasangan KioskPINCheckIn untuk mencatat jam pulang di kiosk
+func KioskPINCheckOut(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		kiosk, err := authenticateKiosk(db, c)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: err.Error()}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var request KioskPINCheckInRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		employee, statusCode, err := verifyKioskPIN(db, kiosk.ID, request)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		attendance, statusCode, err := checkOutEmployee(db, employee)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":          http.StatusOK,
+			"error":         false,
+			"message":       "Employee check-out successful",
+			"full_name":     attendance.FullNameEmployee,
+			"time":          attendance.OutTime,
+			"total_work":    attendance.TotalWork,
+			"early_leaving": attendance.EarlyLeaving,
+			"kiosk_name":    kiosk.KioskName,
+		})
+	}
+}
+
+type KioskQRCheckInRequest struct {
+	Token string `json:"token"`
+}
+
+// kioskFromQRToken memvalidasi QR token yang dipindai karyawan dan mengembalikan kiosk yang masih aktif
+func kioskFromQRToken(db *gorm.DB, token string, secretKey []byte) (models.Kiosk, error) {
+	kioskID, err := verifyKioskQRToken(token, time.Now(), secretKey)
+	if err != nil {
+		return models.Kiosk{}, err
+	}
+
+	var kiosk models.Kiosk
+	if err := db.First(&kiosk, kioskID).Error; err != nil || !kiosk.IsActive {
+		return models.Kiosk{}, errors.New("Kiosk is not available")
+	}
+	return kiosk, nil
+}
+
+// EmployeeKioskQRCheckIn dipakai karyawan yang memindai QR code kiosk dari aplikasinya
+func EmployeeKioskQRCheckIn(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var request KioskQRCheckInRequest
+		if err := c.Bind(&request); err != nil || request.Token == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "QR token is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		kiosk, err := kioskFromQRToken(db, request.Token, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		attendance, statusCode, err := checkInEmployee(db, employee, kiosk.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Employee check-in successful",
+			"time":       attendance.InTime,
+			"late":       attendance.Late,
+			"kiosk_name": kiosk.KioskName,
+		})
+	}
+}
+
+// EmployeeKioskQRCheckOut mencatat jam pulang dengan memindai QR code kiosk dari aplikasi karyawan
+func EmployeeKioskQRCheckOut(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var request KioskQRCheckInRequest
+		if err := c.Bind(&request); err != nil || request.Token == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "QR token is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		kiosk, err := kioskFromQRToken(db, request.Token, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		attendance, statusCode, err := checkOutEmployee(db, employee)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: statusCode, Message: err.Error()}
+			return c.JSON(statusCode, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":          http.StatusOK,
+			"error":         false,
+			"message":       "Employee check-out successful",
+			"time":          attendance.OutTime,
+			"total_work":    attendance.TotalWork,
+			"early_leaving": attendance.EarlyLeaving,
+			"kiosk_name":    kiosk.KioskName,
+		})
+	}
+}
@@ -0,0 +1,731 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const maxKnowledgeBaseSuggestions = 5
+
+// knowledgeBaseStopWords diabaikan saat mencari saran artikel dari draft tiket
+var knowledgeBaseStopWords = map[string]bool{
+	"dan": true, "yang": true, "untuk": true, "dengan": true, "saya": true, "tidak": true, "apa": true,
+	"bagaimana": true, "kenapa": true, "mengapa": true, "dari": true, "pada": true, "ini": true, "itu": true,
+	"the": true, "and": true, "for": true, "how": true, "what": true, "why": true, "with": true, "not": true,
+}
+
+type KnowledgeBaseArticleRequest struct {
+	CategoryID  uint   `json:"category_id"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Tags        string `json:"tags"`
+	IsPublished *bool  `json:"is_published"`
+}
+
+// KnowledgeBaseSuggestedArticle adalah artikel hasil pencarian beserta potongan isi yang relevan
+type KnowledgeBaseSuggestedArticle struct {
+	ID           uint    `json:"id"`
+	Title        string  `json:"title"`
+	CategoryName string  `json:"category_name"`
+	Snippet      string  `json:"snippet"`
+	Rank         float64 `json:"rank"`
+}
+
+// knowledgeBaseAnyWordQuery menyusun tsquery OR dari kata-kata pada teks bebas, misalnya "cuti | tahunan | sisa"
+func knowledgeBaseAnyWordQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := map[string]bool{}
+	var terms []string
+	for _, word := range words {
+		if len([]rune(word)) < 3 || knowledgeBaseStopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+	return strings.Join(terms, " | ")
+}
+
+// suggestKnowledgeBaseArticles mencari artikel terbit yang paling relevan dengan draft subjek/deskripsi tiket
+func suggestKnowledgeBaseArticles(db *gorm.DB, text string) []KnowledgeBaseSuggestedArticle {
+	suggestions := []KnowledgeBaseSuggestedArticle{}
+
+	tsQuery := knowledgeBaseAnyWordQuery(text)
+	if tsQuery == "" {
+		return suggestions
+	}
+
+	db.Model(&models.KnowledgeBaseArticle{}).
+		Select("id, title, category_name, ts_headline('simple', content, to_tsquery('simple', ?), 'MaxWords=30, MinWords=10') AS snippet, ts_rank(search_vector, to_tsquery('simple', ?)) AS rank", tsQuery, tsQuery).
+		Where("is_published = ? AND search_vector @@ to_tsquery('simple', ?)", true, tsQuery).
+		Order("rank DESC").
+		Limit(maxKnowledgeBaseSuggestions).
+		Scan(&suggestions)
+
+	return suggestions
+}
+
+// searchKnowledgeBaseArticles dipakai oleh endpoint admin dan karyawan, q mendukung sintaks websearch (kutip, OR, -kata)
+func searchKnowledgeBaseArticles(c echo.Context, db *gorm.DB, publishedOnly bool) ([]models.KnowledgeBaseArticle, int64, int, int, error) {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 10
+	}
+
+	offset := (page - 1) * perPage
+
+	query := db.Model(&models.KnowledgeBaseArticle{})
+	if publishedOnly {
+		query = query.Where("is_published = ?", true)
+	}
+	if categoryID := c.QueryParam("category_id"); categoryID != "" {
+		query = query.Where("category_id = ?", categoryID)
+	}
+
+	searching := strings.TrimSpace(c.QueryParam("q"))
+	if searching != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('simple', ?)", searching)
+	}
+
+	var totalCount int64
+	query.Count(&totalCount)
+
+	var articles []models.KnowledgeBaseArticle
+	if searching != "" {
+		query = query.Select("knowledge_base_articles.*, ts_rank(search_vector, websearch_to_tsquery('simple', ?)) AS rank", searching).Order("rank DESC")
+	}
+	err = query.Order("id DESC").Offset(offset).Limit(perPage).Find(&articles).Error
+
+	return articles, totalCount, page, perPage, err
+}
+
+// knowledgeBaseDeflectionStats menghitung berapa banyak draft tiket yang terjawab oleh artikel tanpa perlu membuat tiket
+func knowledgeBaseDeflectionStats(db *gorm.DB) map[string]interface{} {
+	var sessions, deflected, converted int64
+	db.Model(&models.KnowledgeBaseSuggestion{}).Where("suggested_count > 0").Count(&sessions)
+	db.Model(&models.KnowledgeBaseSuggestion{}).Where("suggested_count > 0 AND deflected = ?", true).Count(&deflected)
+	db.Model(&models.KnowledgeBaseSuggestion{}).Where("suggested_count > 0 AND helpdesk_id <> 0").Count(&converted)
+
+	deflectionRate := 0.0
+	if sessions > 0 {
+		deflectionRate = math.Round(float64(deflected)/float64(sessions)*10000) / 100
+	}
+
+	return map[string]interface{}{
+		"suggestion_sessions": sessions,
+		"deflected":           deflected,
+		"converted_to_ticket": converted,
+		"abandoned":           sessions - deflected - converted,
+		"deflection_rate":     deflectionRate,
+	}
+}
+
+func CreateKnowledgeBaseCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var category models.KnowledgeBaseCategory
+		if err := c.Bind(&category); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		category.Name = strings.TrimSpace(category.Name)
+		if len(category.Name) < 3 || len(category.Name) > 100 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Category name must be between 3 and 100 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var existingCategory models.KnowledgeBaseCategory
+		if err := db.Where("LOWER(name) = LOWER(?)", category.Name).First(&existingCategory).Error; err == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Category name already exists"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		category.ID = 0
+		category.CreatedAt = &currentTime
+
+		if err := db.Create(&category).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create category"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":     http.StatusCreated,
+			"error":    false,
+			"message":  "Knowledge base category created successfully",
+			"category": category,
+		})
+	}
+}
+
+// GetAllKnowledgeBaseCategories dapat diakses oleh admin maupun karyawan
+func GetAllKnowledgeBaseCategories(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		if _, err := middleware.VerifyToken(tokenString, secretKey); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var categories []models.KnowledgeBaseCategory
+		db.Order("name ASC").Find(&categories)
+
+		// Jumlah artikel terbit per kategori
+		type articleCount struct {
+			CategoryID uint
+			Count      int
+		}
+		var counts []articleCount
+		db.Model(&models.KnowledgeBaseArticle{}).Select("category_id, count(*) as count").Where("is_published = ?", true).Group("category_id").Scan(&counts)
+
+		publishedArticles := map[uint]int{}
+		for _, count := range counts {
+			publishedArticles[count.CategoryID] = count.Count
+		}
+
+		var response []map[string]interface{}
+		for _, category := range categories {
+			response = append(response, map[string]interface{}{
+				"id":                 category.ID,
+				"name":               category.Name,
+				"description":        category.Description,
+				"published_articles": publishedArticles[category.ID],
+				"created_at":         category.CreatedAt,
+				"updated_at":         category.UpdatedAt,
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base categories retrieved successfully",
+			"data":    response,
+		})
+	}
+}
+
+func UpdateKnowledgeBaseCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var category models.KnowledgeBaseCategory
+		if err := db.First(&category, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var updatedCategory models.KnowledgeBaseCategory
+		if err := c.Bind(&updatedCategory); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		updatedCategory.Name = strings.TrimSpace(updatedCategory.Name)
+		if updatedCategory.Name != "" && updatedCategory.Name != category.Name {
+			if len(updatedCategory.Name) < 3 || len(updatedCategory.Name) > 100 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Category name must be between 3 and 100 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var existingCategory models.KnowledgeBaseCategory
+			if err := db.Where("LOWER(name) = LOWER(?) AND id <> ?", updatedCategory.Name, category.ID).First(&existingCategory).Error; err == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Category name already exists"}
+				return c.JSON(http.StatusConflict, errorResponse)
+			}
+
+			category.Name = updatedCategory.Name
+			// Nama kategori pada artikel ikut diperbarui
+			db.Model(&models.KnowledgeBaseArticle{}).Where("category_id = ?", category.ID).Update("category_name", category.Name)
+		}
+		if updatedCategory.Description != "" {
+			category.Description = updatedCategory.Description
+		}
+		category.UpdatedAt = time.Now()
+
+		if err := db.Save(&category).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update category"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Knowledge base category updated successfully",
+			"category": category,
+		})
+	}
+}
+
+func DeleteKnowledgeBaseCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var category models.KnowledgeBaseCategory
+		if err := db.First(&category, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var articleCount int64
+		db.Model(&models.KnowledgeBaseArticle{}).Where("category_id = ?", category.ID).Count(&articleCount)
+		if articleCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: fmt.Sprintf("Category still has %d articles", articleCount)}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		db.Delete(&category)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base category deleted successfully",
+		})
+	}
+}
+
+func CreateKnowledgeBaseArticleByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request KnowledgeBaseArticleRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.CategoryID == 0 || request.Title == "" || request.Content == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Category, title and content are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(request.Title) < 5 || len(request.Title) > 200 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Article title must be between 5 and 200 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var category models.KnowledgeBaseCategory
+		if err := db.First(&category, request.CategoryID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		currentTime := time.Now()
+		article := models.KnowledgeBaseArticle{
+			CategoryID:   category.ID,
+			CategoryName: category.Name,
+			Title:        request.Title,
+			Content:      request.Content,
+			Tags:         request.Tags,
+			IsPublished:  request.IsPublished != nil && *request.IsPublished,
+			AuthorName:   adminUser.FirstName + " " + adminUser.LastName,
+			CreatedAt:    &currentTime,
+		}
+
+		if err := db.Create(&article).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create article"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Knowledge base article created successfully",
+			"article": article,
+		})
+	}
+}
+
+// GetAllKnowledgeBaseArticlesByAdmin menampilkan artikel termasuk draft, mendukung pencarian full-text lewat q
+func GetAllKnowledgeBaseArticlesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		articles, totalCount, page, perPage, err := searchKnowledgeBaseArticles(c, db, false)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching knowledge base articles"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base articles retrieved successfully",
+			"data":    articles,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetKnowledgeBaseArticleByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var article models.KnowledgeBaseArticle
+		if err := db.First(&article, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Article not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base article retrieved successfully",
+			"article": article,
+		})
+	}
+}
+
+func UpdateKnowledgeBaseArticleByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var article models.KnowledgeBaseArticle
+		if err := db.First(&article, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Article not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request KnowledgeBaseArticleRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.CategoryID != 0 && request.CategoryID != article.CategoryID {
+			var category models.KnowledgeBaseCategory
+			if err := db.First(&category, request.CategoryID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			article.CategoryID = category.ID
+			article.CategoryName = category.Name
+		}
+
+		if request.Title != "" {
+			if len(request.Title) < 5 || len(request.Title) > 200 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Article title must be between 5 and 200 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			article.Title = request.Title
+		}
+		if request.Content != "" {
+			article.Content = request.Content
+		}
+		if request.Tags != "" {
+			article.Tags = request.Tags
+		}
+		if request.IsPublished != nil {
+			article.IsPublished = *request.IsPublished
+		}
+		article.UpdatedAt = time.Now()
+
+		if err := db.Save(&article).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update article"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base article updated successfully",
+			"article": article,
+		})
+	}
+}
+
+func DeleteKnowledgeBaseArticleByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var article models.KnowledgeBaseArticle
+		if err := db.First(&article, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Article not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Delete(&article)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base article deleted successfully",
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type KnowledgeBaseFeedbackRequest struct {
+	Helpful bool `json:"helpful"`
+}
+
+// GetAllKnowledgeBaseArticlesByEmployee menampilkan artikel yang sudah terbit, mendukung pencarian full-text lewat q
+func GetAllKnowledgeBaseArticlesByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		articles, totalCount, page, perPage, err := searchKnowledgeBaseArticles(c, db, true)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching knowledge base articles"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base articles retrieved successfully",
+			"data":    articles,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetKnowledgeBaseArticleByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var article models.KnowledgeBaseArticle
+		if err := db.Where("id = ? AND is_published = ?", c.Param("id"), true).First(&article).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Article not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Model(&article).UpdateColumn("view_count", gorm.Expr("view_count + ?", 1))
+		article.ViewCount++
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Knowledge base article retrieved successfully",
+			"article": article,
+		})
+	}
+}
+
+func SubmitKnowledgeBaseFeedbackByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var article models.KnowledgeBaseArticle
+		if err := db.Where("id = ? AND is_published = ?", c.Param("id"), true).First(&article).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Article not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request KnowledgeBaseFeedbackRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		column := "not_helpful_count"
+		if request.Helpful {
+			column = "helpful_count"
+		}
+		db.Model(&article).UpdateColumn(column, gorm.Expr(column+" + ?", 1))
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Thank you for your feedback",
+		})
+	}
+}
+
+// ResolveKnowledgeBaseSuggestionByEmployee dipanggil ketika artikel yang disarankan sudah menjawab pertanyaan karyawan
+// sehingga tiket tidak perlu dibuat (dihitung sebagai deflection)
+func ResolveKnowledgeBaseSuggestionByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var suggestion models.KnowledgeBaseSuggestion
+		if err := db.Where("id = ? AND employee_id = ?", c.Param("id"), employee.ID).First(&suggestion).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Suggestion not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if suggestion.HelpdeskID != 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "A helpdesk ticket has already been filed for this suggestion"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		suggestion.Deflected = true
+		suggestion.UpdatedAt = time.Now()
+		db.Save(&suggestion)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Glad the article helped",
+			"suggestion": suggestion,
+		})
+	}
+}
+
+// createKnowledgeBaseSuggestion menyimpan sesi saran artikel untuk draft tiket karyawan
+func createKnowledgeBaseSuggestion(db *gorm.DB, employee models.Employee, draftSubject string, articles []KnowledgeBaseSuggestedArticle) models.KnowledgeBaseSuggestion {
+	var articleIDs []string
+	for _, article := range articles {
+		articleIDs = append(articleIDs, strconv.FormatUint(uint64(article.ID), 10))
+	}
+
+	currentTime := time.Now()
+	suggestion := models.KnowledgeBaseSuggestion{
+		EmployeeID:     employee.ID,
+		DraftSubject:   draftSubject,
+		ArticleIDs:     strings.Join(articleIDs, ","),
+		SuggestedCount: len(articles),
+		CreatedAt:      &currentTime,
+	}
+	db.Create(&suggestion)
+
+	return suggestion
+}
@@ -764,6 +764,7 @@ func GetAllEmployeesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				PaySlipType:              emp.PaySlipType,
 				IsActive:                 *emp.IsActive,
 				PaidStatus:               emp.PaidStatus,
+				AvatarURL:                emp.AvatarURL,
 				MaritalStatus:            emp.MaritalStatus,
 				Religion:                 emp.Religion,
 				BloodGroup:               emp.BloodGroup,
@@ -1453,6 +1454,7 @@ func GetEmployeeByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			PaySlipType:              employee.PaySlipType,
 			IsActive:                 *employee.IsActive,
 			PaidStatus:               employee.PaidStatus,
+			AvatarURL:                employee.AvatarURL,
 			MaritalStatus:            employee.MaritalStatus,
 			Religion:                 employee.Religion,
 			BloodGroup:               employee.BloodGroup,
@@ -1532,6 +1534,8 @@ func UpdateEmployeeAccountByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"})
 		}
 
+		previousEmployee := existingEmployee
+
 		var updatedEmployee models.Employee
 		if err := c.Bind(&updatedEmployee); err != nil {
 			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"})
@@ -1804,6 +1808,8 @@ func UpdateEmployeeAccountByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update employee data"})
 		}
 
+		recordEmploymentChanges(db, previousEmployee, existingEmployee, adminUser, "Updated through employee account")
+
 		// Exclude PayrollInfo from the response
 		employeeWithoutPayrollInfo := helper.EmployeeResponse{
 			ID:                       existingEmployee.ID,
@@ -1828,6 +1834,7 @@ func UpdateEmployeeAccountByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFun
 			PaySlipType:              existingEmployee.PaySlipType,
 			IsActive:                 *existingEmployee.IsActive,
 			PaidStatus:               existingEmployee.PaidStatus,
+			AvatarURL:                existingEmployee.AvatarURL,
 			MaritalStatus:            existingEmployee.MaritalStatus,
 			Religion:                 existingEmployee.Religion,
 			BloodGroup:               existingEmployee.BloodGroup,
@@ -2019,6 +2026,9 @@ func ExitEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Update data employee di database
 		db.Save(&employee)
 
+		// Mulai (atau hubungkan) checklist offboarding dan final settlement
+		linkExitToOffboarding(db, employee, exitData)
+
 		// Respond with success
 		successResponse := helper.Response{
 			Code:    http.StatusOK,
@@ -2250,6 +2260,9 @@ func DeleteExitEmployeeByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		// Checklist offboarding tetap disimpan, hanya dilepas dari data exit yang dihapus
+		db.Model(&models.OffboardingChecklist{}).Where("exit_employee_id = ?", exitEmployee.ID).Update("exit_employee_id", 0)
+
 		// Respond with success
 		successResponse := helper.Response{
 			Code:    http.StatusOK,
@@ -0,0 +1,628 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type offboardingTaskTemplate struct {
+	Title        string
+	Description  string
+	Category     string
+	AssigneeRole string
+	DueDays      int // Jumlah hari dari hari kerja terakhir, boleh negatif
+}
+
+// Kategori Account Deactivation, Exit Interview dan Final Settlement diselesaikan otomatis jika datanya sudah lengkap
+var defaultOffboardingTasks = []offboardingTaskTemplate{
+	{Title: "Return company assets", Description: "Kumpulkan laptop, ID card, kunci dan perangkat kerja lain milik perusahaan.", Category: "Asset Return", AssigneeRole: "IT", DueDays: 0},
+	{Title: "Knowledge handover", Description: "Pastikan dokumentasi pekerjaan dan tanggung jawab yang berjalan sudah diserahterimakan ke anggota tim.", Category: "Knowledge Handover", AssigneeRole: "Manager", DueDays: -3},
+	{Title: "Deactivate accounts", Description: "Nonaktifkan email perusahaan, akun sistem internal dan akses HR Harmony karyawan.", Category: "Account Deactivation", AssigneeRole: "IT", DueDays: 0},
+	{Title: "Conduct exit interview", Description: "Lakukan exit interview dan catat hasilnya pada data exit karyawan.", Category: "Exit Interview", AssigneeRole: "HR", DueDays: -1},
+	{Title: "Finalize final settlement", Description: "Hitung dan finalisasi gaji terakhir, penggantian cuti, pesangon serta pelunasan pinjaman karyawan.", Category: "Final Settlement", AssigneeRole: "HR", DueDays: 7},
+}
+
+type OffboardingRequest struct {
+	EmployeeID        uint   `json:"employee_id"`
+	LastWorkingDate   string `json:"last_working_date"`  // Format: yyyy-mm-dd
+	TerminationReason string `json:"termination_reason"` // Lihat terminationReasons, boleh diisi saat perhitungan final settlement
+}
+
+// employeeManager adalah kepala departemen karyawan, kecuali karyawan itu sendiri yang merupakan kepala departemen
+func employeeManager(db *gorm.DB, employee models.Employee) *models.Employee {
+	var department models.Department
+	if err := db.First(&department, employee.DepartmentID).Error; err != nil || department.EmployeeID == 0 || department.EmployeeID == employee.ID {
+		return nil
+	}
+
+	var manager models.Employee
+	if err := db.Where("id = ? AND is_exit = ?", department.EmployeeID, false).First(&manager).Error; err != nil {
+		return nil
+	}
+	return &manager
+}
+
+// createOffboardingChecklist membuat checklist offboarding dari daftar tugas standar
+func createOffboardingChecklist(tx *gorm.DB, employee models.Employee, exitEmployeeID uint, terminationReason string, lastWorkingDate time.Time, manager, itEmployee *models.Employee) (models.OffboardingChecklist, error) {
+	currentTime := time.Now()
+	checklist := models.OffboardingChecklist{
+		EmployeeID:        employee.ID,
+		FullName:          employee.FullName,
+		ExitEmployeeID:    exitEmployeeID,
+		TerminationReason: terminationReason,
+		LastWorkingDate:   lastWorkingDate.Format("2006-01-02"),
+		Status:            "In Progress",
+		CreatedAt:         &currentTime,
+	}
+	if manager != nil {
+		checklist.ManagerID = manager.ID
+		checklist.ManagerName = manager.FullName
+	}
+
+	for i, templateTask := range defaultOffboardingTasks {
+		task := models.OffboardingTask{
+			EmployeeID:   employee.ID,
+			Title:        templateTask.Title,
+			Description:  templateTask.Description,
+			Category:     templateTask.Category,
+			AssigneeRole: templateTask.AssigneeRole,
+			AssigneeName: "HR",
+			DueDate:      lastWorkingDate.AddDate(0, 0, templateTask.DueDays).Format("2006-01-02"),
+			SortOrder:    i + 1,
+			Status:       "Pending",
+		}
+
+		switch {
+		case templateTask.AssigneeRole == "Manager" && manager != nil:
+			task.AssigneeEmployeeID = manager.ID
+			task.AssigneeName = manager.FullName
+		case templateTask.AssigneeRole == "IT" && itEmployee != nil:
+			task.AssigneeEmployeeID = itEmployee.ID
+			task.AssigneeName = itEmployee.FullName
+		}
+
+		checklist.Tasks = append(checklist.Tasks, task)
+	}
+
+	err := tx.Create(&checklist).Error
+	return checklist, err
+}
+
+// startOffboarding membuat checklist offboarding beserta penanggung jawab tugasnya dan mengirim notifikasi
+func startOffboarding(db *gorm.DB, employee models.Employee, exitEmployeeID uint, terminationReason string, lastWorkingDate time.Time) (models.OffboardingChecklist, error) {
+	manager := employeeManager(db, employee)
+
+	var itEmployee *models.Employee
+	if itHead, ok := itOnboardingAssignee(db); ok && itHead.ID != employee.ID {
+		itEmployee = &itHead
+	}
+
+	checklist, err := createOffboardingChecklist(db, employee, exitEmployeeID, terminationReason, lastWorkingDate, manager, itEmployee)
+	if err != nil {
+		return checklist, err
+	}
+
+	notifyOffboardingAssignees(db, checklist)
+	return checklist, nil
+}
+
+// linkExitToOffboarding dipanggil saat proses exit karyawan dicatat, checklist dibuat jika belum ada
+func linkExitToOffboarding(db *gorm.DB, employee models.Employee, exitData models.ExitEmployee) {
+	terminationReason := ""
+	for reason := range terminationReasons {
+		if strings.EqualFold(reason, strings.TrimSpace(exitData.ExitName)) {
+			terminationReason = reason
+		}
+	}
+
+	// Checklist yang sudah Completed milik proses exit sebelumnya sehingga dibuatkan checklist baru
+	var checklist models.OffboardingChecklist
+	if err := db.Where("employee_id = ? AND status <> ?", employee.ID, "Completed").Order("id DESC").First(&checklist).Error; err != nil {
+		lastWorkingDate, err := time.Parse("2006-01-02", exitData.ExitDate)
+		if err != nil {
+			lastWorkingDate = time.Now()
+		}
+		if _, err := startOffboarding(db, employee, exitData.ID, terminationReason, lastWorkingDate); err != nil {
+			fmt.Println("Failed to create offboarding checklist:", err)
+		}
+		return
+	}
+
+	updates := map[string]interface{}{"exit_employee_id": exitData.ID, "updated_at": time.Now()}
+	if checklist.TerminationReason == "" && terminationReason != "" {
+		updates["termination_reason"] = terminationReason
+	}
+	db.Model(&models.OffboardingChecklist{}).Where("id = ?", checklist.ID).Updates(updates)
+}
+
+// notifyOffboardingAssignees mengirim email ke karyawan yang ditugaskan (IT dan Manager)
+func notifyOffboardingAssignees(db *gorm.DB, checklist models.OffboardingChecklist) {
+	for _, task := range checklist.Tasks {
+		if task.AssigneeEmployeeID == 0 {
+			continue
+		}
+
+		var assignee models.Employee
+		if err := db.First(&assignee, task.AssigneeEmployeeID).Error; err != nil || assignee.Email == "" {
+			continue
+		}
+
+		if err := helper.SendOffboardingTaskNotification(assignee.Email, assignee.FullName, checklist.FullName, task.Title, task.DueDate); err != nil {
+			fmt.Println("Failed to send offboarding task notification:", err)
+		}
+	}
+}
+
+// syncOffboardingAutoTasks menyelesaikan tugas yang datanya sudah tercatat di sistem
+func syncOffboardingAutoTasks(db *gorm.DB, checklist *models.OffboardingChecklist) {
+	if checklist.Status == "Completed" {
+		return
+	}
+
+	var employee models.Employee
+	if err := db.First(&employee, checklist.EmployeeID).Error; err != nil {
+		return
+	}
+
+	changed := false
+	for i := range checklist.Tasks {
+		task := &checklist.Tasks[i]
+		if task.Status == "Completed" {
+			continue
+		}
+
+		done := false
+		switch task.Category {
+		case "Account Deactivation":
+			done = employee.IsActive != nil && !*employee.IsActive
+		case "Exit Interview":
+			var exitEmployee models.ExitEmployee
+			if err := db.Where("employee_id = ?", employee.ID).First(&exitEmployee).Error; err == nil {
+				done = strings.TrimSpace(exitEmployee.ExitInterview) != ""
+			}
+		case "Final Settlement":
+			var finalizedCount int64
+			db.Model(&models.FinalSettlement{}).Where("checklist_id = ? AND status = ?", checklist.ID, "Finalized").Count(&finalizedCount)
+			done = finalizedCount > 0
+		}
+
+		if done {
+			if err := completeOffboardingTask(db, task, "System", 0, "System", "Completed automatically from employee data"); err == nil {
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		refreshOffboardingChecklistStatus(db, checklist)
+	}
+}
+
+func completeOffboardingTask(db *gorm.DB, task *models.OffboardingTask, role string, completedByID uint, completedByName, note string) error {
+	currentTime := time.Now()
+	task.Status = "Completed"
+	task.CompletedByRole = role
+	task.CompletedByID = completedByID
+	task.CompletedByName = completedByName
+	task.CompletedAt = &currentTime
+	if note != "" {
+		task.Note = note
+	}
+	return db.Save(task).Error
+}
+
+// refreshOffboardingChecklistStatus menandai checklist selesai jika semua tugas selesai (dan sebaliknya)
+func refreshOffboardingChecklistStatus(db *gorm.DB, checklist *models.OffboardingChecklist) {
+	var pending int64
+	db.Model(&models.OffboardingTask{}).Where("checklist_id = ? AND status <> ?", checklist.ID, "Completed").Count(&pending)
+
+	status := "In Progress"
+	var completedAt *time.Time
+	if pending == 0 {
+		currentTime := time.Now()
+		status = "Completed"
+		completedAt = &currentTime
+	}
+	if status == checklist.Status {
+		return
+	}
+
+	checklist.Status = status
+	checklist.CompletedAt = completedAt
+	checklist.UpdatedAt = time.Now()
+	db.Model(&models.OffboardingChecklist{}).Where("id = ?", checklist.ID).Updates(map[string]interface{}{
+		"status":       checklist.Status,
+		"completed_at": checklist.CompletedAt,
+		"updated_at":   checklist.UpdatedAt,
+	})
+}
+
+func offboardingProgress(checklist models.OffboardingChecklist) map[string]interface{} {
+	today := time.Now().Format("2006-01-02")
+	completed, overdue := 0, 0
+	for _, task := range checklist.Tasks {
+		if task.Status == "Completed" {
+			completed++
+		} else if task.DueDate < today {
+			overdue++
+		}
+	}
+
+	percentage := 0.0
+	if len(checklist.Tasks) > 0 {
+		percentage = math.Round(float64(completed)/float64(len(checklist.Tasks))*10000) / 100
+	}
+
+	return map[string]interface{}{
+		"total_tasks":     len(checklist.Tasks),
+		"completed_tasks": completed,
+		"overdue_tasks":   overdue,
+		"percentage":      percentage,
+	}
+}
+
+func preloadOffboardingTasks(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC").Order("id ASC") })
+}
+
+// CreateOffboardingByAdmin memulai offboarding sebelum exit dicatat, misalnya saat karyawan menjalani masa pemberitahuan resign
+func CreateOffboardingByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request OffboardingRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		lastWorkingDate, err := time.Parse("2006-01-02", request.LastWorkingDate)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid last_working_date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.TerminationReason != "" {
+			if _, ok := terminationReasons[request.TerminationReason]; !ok {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid termination reason. Allowed values: " + terminationReasonList()}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		var employee models.Employee
+		if err := db.Where("id = ? AND is_client = ?", request.EmployeeID, false).First(&employee).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var existingCount int64
+		db.Model(&models.OffboardingChecklist{}).Where("employee_id = ? AND status <> ?", employee.ID, "Completed").Count(&existingCount)
+		if existingCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "An active offboarding checklist already exists for the employee"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		// Hubungkan dengan data exit jika exit sudah dicatat sebelumnya
+		var exitEmployee models.ExitEmployee
+		db.Where("employee_id = ?", employee.ID).First(&exitEmployee)
+
+		checklist, err := startOffboarding(db, employee, exitEmployee.ID, request.TerminationReason, lastWorkingDate)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create offboarding checklist"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		syncOffboardingAutoTasks(db, &checklist)
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":      http.StatusCreated,
+			"error":     false,
+			"message":   "Offboarding checklist created successfully",
+			"checklist": checklist,
+			"progress":  offboardingProgress(checklist),
+		})
+	}
+}
+
+func GetAllOffboardingChecklistsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.OffboardingChecklist{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			query = query.Where("full_name ILIKE ?", "%"+searching+"%")
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var checklists []models.OffboardingChecklist
+		if err := preloadOffboardingTasks(query).Order("id DESC").Offset(offset).Limit(perPage).Find(&checklists).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching offboarding checklists"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var response []map[string]interface{}
+		for i := range checklists {
+			syncOffboardingAutoTasks(db, &checklists[i])
+
+			settlementStatus := "Not Calculated"
+			var settlement models.FinalSettlement
+			if err := db.Where("checklist_id = ?", checklists[i].ID).First(&settlement).Error; err == nil {
+				settlementStatus = settlement.Status
+			}
+
+			response = append(response, map[string]interface{}{
+				"checklist":         checklists[i],
+				"progress":          offboardingProgress(checklists[i]),
+				"settlement_status": settlementStatus,
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Offboarding checklists retrieved successfully",
+			"checklists": response,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetOffboardingChecklistByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		if err := preloadOffboardingTasks(db).First(&checklist, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Offboarding checklist not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		syncOffboardingAutoTasks(db, &checklist)
+
+		var settlement *models.FinalSettlement
+		var existingSettlement models.FinalSettlement
+		if err := db.Where("checklist_id = ?", checklist.ID).First(&existingSettlement).Error; err == nil {
+			settlement = &existingSettlement
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Offboarding checklist retrieved successfully",
+			"checklist":  checklist,
+			"progress":   offboardingProgress(checklist),
+			"settlement": settlement,
+		})
+	}
+}
+
+// UpdateOffboardingTaskByAdmin menyelesaikan, membuka kembali atau mengalihkan tugas offboarding
+func UpdateOffboardingTaskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var task models.OffboardingTask
+		if err := db.First(&task, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Offboarding task not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request OnboardingTaskUpdateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Status != "" && request.Status != "Completed" && request.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid status. Allowed values: Completed, Pending"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Tugas final settlement hanya selesai melalui finalisasi perhitungan
+		if task.Category == "Final Settlement" && request.Status != "" && request.Status != task.Status {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Final settlement task is completed by finalizing the settlement"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		db.First(&checklist, task.ChecklistID)
+
+		if request.AssigneeEmployeeID != 0 && request.AssigneeEmployeeID != task.AssigneeEmployeeID {
+			var assignee models.Employee
+			if err := db.Where("id = ? AND is_client = ? AND is_exit = ?", request.AssigneeEmployeeID, false, false).First(&assignee).Error; err != nil || assignee.ID == task.EmployeeID {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Assignee must be another active employee"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			task.AssigneeEmployeeID = assignee.ID
+			task.AssigneeName = assignee.FullName
+
+			if assignee.Email != "" {
+				if err := helper.SendOffboardingTaskNotification(assignee.Email, assignee.FullName, checklist.FullName, task.Title, task.DueDate); err != nil {
+					fmt.Println("Failed to send offboarding task notification:", err)
+				}
+			}
+		}
+
+		if request.Note != "" {
+			task.Note = request.Note
+		}
+
+		switch {
+		case request.Status == "Completed" && task.Status != "Completed":
+			err = completeOffboardingTask(db, &task, "Admin", adminUser.ID, adminUser.Username, request.Note)
+		case request.Status == "Pending" && task.Status == "Completed":
+			task.Status = "Pending"
+			task.CompletedByRole = ""
+			task.CompletedByID = 0
+			task.CompletedByName = ""
+			task.CompletedAt = nil
+			err = db.Save(&task).Error
+		default:
+			err = db.Save(&task).Error
+		}
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update offboarding task"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		refreshOffboardingChecklistStatus(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":             http.StatusOK,
+			"error":            false,
+			"message":          "Offboarding task updated successfully",
+			"task":             task,
+			"checklist_status": checklist.Status,
+		})
+	}
+}
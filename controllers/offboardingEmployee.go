@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetOffboardingByEmployee menampilkan checklist offboarding milik karyawan yang login beserta final settlement yang sudah difinalisasi
+func GetOffboardingByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		if err := preloadOffboardingTasks(db).Where("employee_id = ?", employee.ID).Order("id DESC").First(&checklist).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Offboarding checklist not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		syncOffboardingAutoTasks(db, &checklist)
+
+		// Draft perhitungan hanya dapat dilihat oleh HR
+		var settlement *models.FinalSettlement
+		var finalized models.FinalSettlement
+		if err := db.Where("checklist_id = ? AND status = ?", checklist.ID, "Finalized").First(&finalized).Error; err == nil {
+			settlement = &finalized
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Offboarding checklist retrieved successfully",
+			"checklist":  checklist,
+			"progress":   offboardingProgress(checklist),
+			"settlement": settlement,
+		})
+	}
+}
+
+func DownloadFinalSettlementPDFByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var settlement models.FinalSettlement
+		if err := db.Where("employee_id = ? AND status = ?", employee.ID, "Finalized").Order("id DESC").First(&settlement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Final settlement not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamFinalSettlementPDF(c, settlement)
+	}
+}
+
+// GetOffboardingTasksByEmployee menampilkan tugas offboarding karyawan lain yang ditugaskan ke karyawan yang login (IT/Manager)
+func GetOffboardingTasksByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.OffboardingTask{}).Where("assignee_employee_id = ?", employee.ID)
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var tasks []models.OffboardingTask
+		query.Order("status DESC").Order("due_date ASC").Offset(offset).Limit(perPage).Find(&tasks)
+
+		// Nama karyawan yang keluar diambil dari checklist
+		var checklistIDs []uint
+		for _, task := range tasks {
+			checklistIDs = append(checklistIDs, task.ChecklistID)
+		}
+		checklistNames := map[uint]string{}
+		if len(checklistIDs) > 0 {
+			var checklists []models.OffboardingChecklist
+			db.Where("id IN ?", checklistIDs).Find(&checklists)
+			for _, checklist := range checklists {
+				checklistNames[checklist.ID] = checklist.FullName
+			}
+		}
+
+		var response []map[string]interface{}
+		for _, task := range tasks {
+			response = append(response, map[string]interface{}{
+				"task":                  task,
+				"leaving_employee_name": checklistNames[task.ChecklistID],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Offboarding tasks retrieved successfully",
+			"tasks":   response,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func CompleteOffboardingTaskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var task models.OffboardingTask
+		if err := db.First(&task, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Offboarding task not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if task.AssigneeEmployeeID != employee.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Offboarding task is not assigned to the employee"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		if task.Status == "Completed" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Offboarding task is already completed"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request OnboardingTaskUpdateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if err := completeOffboardingTask(db, &task, "Employee", employee.ID, employee.FullName, request.Note); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to complete offboarding task"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var checklist models.OffboardingChecklist
+		db.First(&checklist, task.ChecklistID)
+		refreshOffboardingChecklistStatus(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":             http.StatusOK,
+			"error":            false,
+			"message":          "Offboarding task completed successfully",
+			"task":             task,
+			"checklist_status": checklist.Status,
+		})
+	}
+}
@@ -0,0 +1,1064 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var validLetterTemplateTypes = map[string]string{
+	"Offer Letter":        "OL",
+	"Employment Contract": "EC",
+}
+
+// letterPlaceholderPattern mencocokkan placeholder seperti {{name}} atau {{ salary }}
+var letterPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+var letterPlaceholders = map[string]string{
+	"name":             "Nama lengkap kandidat/karyawan",
+	"first_name":       "Nama depan",
+	"email":            "Email penerima",
+	"job_title":        "Judul lowongan",
+	"designation":      "Jabatan",
+	"department":       "Departemen",
+	"salary":           "Gaji pokok per bulan",
+	"allowances":       "Daftar tunjangan",
+	"total_allowances": "Total tunjangan per bulan",
+	"total_package":    "Gaji pokok ditambah tunjangan",
+	"start_date":       "Tanggal mulai bekerja",
+	"expiry_date":      "Batas waktu respon",
+	"letter_number":    "Nomor surat",
+	"date":             "Tanggal surat dibuat",
+}
+
+type LetterTemplateRequest struct {
+	Name         string `json:"name"`
+	TemplateType string `json:"template_type"`
+	Subject      string `json:"subject"`
+	Body         string `json:"body"`
+	IsActive     *bool  `json:"is_active"`
+}
+
+type OfferLetterAllowanceRequest struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+type OfferLetterRequest struct {
+	TemplateID  uint                          `json:"template_id"`
+	CandidateID uint                          `json:"candidate_id"`
+	EmployeeID  uint                          `json:"employee_id"`
+	BasicSalary float64                       `json:"basic_salary"`
+	Allowances  []OfferLetterAllowanceRequest `json:"allowances"`
+	StartDate   string                        `json:"start_date"`  // Format: yyyy-mm-dd
+	ExpiryDays  int                           `json:"expiry_days"` // Default 7 hari
+}
+
+// validateLetterTemplate memastikan semua placeholder pada subjek dan isi template dikenali
+func validateLetterTemplate(subject, body string) error {
+	var unknown []string
+	for _, match := range letterPlaceholderPattern.FindAllStringSubmatch(subject+"\n"+body, -1) {
+		if _, ok := letterPlaceholders[match[1]]; !ok {
+			unknown = append(unknown, match[1])
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("Unknown placeholders: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func renderLetterTemplate(text string, values map[string]string) string {
+	return letterPlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return values[letterPlaceholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+// offerLetterValues menyiapkan nilai placeholder dari data surat
+func offerLetterValues(letter models.OfferLetter) map[string]string {
+	allowanceLines := []string{}
+	for _, allowance := range letter.Allowances {
+		allowanceLines = append(allowanceLines, fmt.Sprintf("- %s: %s", allowance.Name, helper.FormatToIDR(allowance.Amount)))
+	}
+	allowances := "-"
+	if len(allowanceLines) > 0 {
+		allowances = strings.Join(allowanceLines, "\n")
+	}
+
+	firstName := letter.RecipientName
+	if names := strings.Fields(letter.RecipientName); len(names) > 0 {
+		firstName = names[0]
+	}
+
+	return map[string]string{
+		"name":             letter.RecipientName,
+		"first_name":       firstName,
+		"email":            letter.RecipientEmail,
+		"job_title":        letter.JobTitle,
+		"designation":      letter.DesignationName,
+		"department":       letter.DepartmentName,
+		"salary":           helper.FormatToIDR(letter.BasicSalary),
+		"allowances":       allowances,
+		"total_allowances": helper.FormatToIDR(letter.TotalAllowances),
+		"total_package":    helper.FormatToIDR(letter.BasicSalary + letter.TotalAllowances),
+		"start_date":       formatLetterDate(letter.StartDate),
+		"expiry_date":      formatLetterDate(letter.ExpiryDate),
+		"letter_number":    letter.LetterNumber,
+		"date":             time.Now().Format("02 January 2006"),
+	}
+}
+
+func formatLetterDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format("02 January 2006")
+}
+
+// storeOfferLetterPDF membuat ulang PDF surat dan menyimpannya ke storage
+func storeOfferLetterPDF(letter *models.OfferLetter) error {
+	acknowledgement := ""
+	if letter.Status == "Accepted" && letter.AcknowledgedAt != nil {
+		acknowledgement = fmt.Sprintf("Accepted electronically by %s on %s (IP %s)", letter.AcknowledgedName, letter.AcknowledgedAt.Format("02 January 2006 15:04:05 MST"), letter.AcknowledgedIP)
+	}
+
+	// Tanggal surat mengikuti tanggal terbit nomor surat, bukan waktu PDF dibuat ulang
+	issuedAt := time.Now()
+	if letter.CreatedAt != nil {
+		issuedAt = *letter.CreatedAt
+	}
+
+	pdfData, err := helper.GenerateLetterPDF(letter.LetterNumber, letter.Subject, letter.Content, acknowledgement, issuedAt)
+	if err != nil {
+		return err
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		return err
+	}
+
+	if letter.DocumentKey == "" {
+		letter.DocumentKey = fmt.Sprintf("offer_letters/%d/%d.pdf", letter.ID, time.Now().UnixNano())
+	}
+	letter.DocumentName = strings.ReplaceAll(letter.LetterNumber, "/", "-") + ".pdf"
+	return fileStorage.Upload(letter.DocumentKey, pdfData, "application/pdf")
+}
+
+// expireOfferLetters menandai surat terkirim yang melewati batas waktu respon sebagai Expired
+func expireOfferLetters(db *gorm.DB) {
+	db.Model(&models.OfferLetter{}).
+		Where("status = ? AND expiry_date < ?", "Sent", time.Now().Format("2006-01-02")).
+		Updates(map[string]interface{}{"status": "Expired", "updated_at": time.Now()})
+}
+
+func offerLetterResponseURL(token string) string {
+	return fmt.Sprintf("%s/careers/offers/%s", strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/"), token)
+}
+
+func streamOfferLetterPDF(c echo.Context, letter models.OfferLetter) error {
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	reader, err := fileStorage.Open(letter.DocumentKey)
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter document not found"}
+		return c.JSON(http.StatusNotFound, errorResponse)
+	}
+	defer reader.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", letter.DocumentName))
+	return c.Stream(http.StatusOK, "application/pdf", reader)
+}
+
+// deleteCandidateOfferLetters menghapus surat milik kandidat beserta dokumennya
+func deleteCandidateOfferLetters(db *gorm.DB, candidateID uint) {
+	var letters []models.OfferLetter
+	db.Where("candidate_id = ?", candidateID).Find(&letters)
+	if len(letters) == 0 {
+		return
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	for _, letter := range letters {
+		if err == nil && letter.DocumentKey != "" {
+			fileStorage.Delete(letter.DocumentKey)
+		}
+		db.Where("offer_letter_id = ?", letter.ID).Delete(&models.OfferLetterAllowance{})
+		db.Delete(&letter)
+	}
+}
+
+func GetLetterTemplatesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		query := db.Model(&models.LetterTemplate{})
+		if templateType := c.QueryParam("template_type"); templateType != "" {
+			query = query.Where("template_type = ?", templateType)
+		}
+
+		var templates []models.LetterTemplate
+		query.Order("id ASC").Find(&templates)
+
+		var placeholders []map[string]string
+		for name, description := range letterPlaceholders {
+			placeholders = append(placeholders, map[string]string{"placeholder": "{{" + name + "}}", "description": description})
+		}
+		sort.Slice(placeholders, func(i, j int) bool { return placeholders[i]["placeholder"] < placeholders[j]["placeholder"] })
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Letter templates retrieved successfully",
+			"templates":    templates,
+			"placeholders": placeholders,
+		})
+	}
+}
+
+func CreateLetterTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request LetterTemplateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(strings.TrimSpace(request.Name)) < 3 || strings.TrimSpace(request.Subject) == "" || strings.TrimSpace(request.Body) == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name (min 3 characters), subject and body are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if _, ok := validLetterTemplateTypes[request.TemplateType]; !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid template type. Allowed values: Offer Letter, Employment Contract"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if err := validateLetterTemplate(request.Subject, request.Body); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		template := models.LetterTemplate{
+			Name:         strings.TrimSpace(request.Name),
+			TemplateType: request.TemplateType,
+			Subject:      request.Subject,
+			Body:         request.Body,
+			IsActive:     request.IsActive == nil || *request.IsActive,
+			CreatedAt:    &currentTime,
+		}
+		if err := db.Create(&template).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create letter template"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":     http.StatusCreated,
+			"error":    false,
+			"message":  "Letter template created successfully",
+			"template": template,
+		})
+	}
+}
+
+// UpdateLetterTemplateByAdmin mengubah template, surat yang sudah dibuat tetap memakai isi lama
+func UpdateLetterTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var template models.LetterTemplate
+		if err := db.First(&template, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter template not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request LetterTemplateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if name := strings.TrimSpace(request.Name); name != "" {
+			if len(name) < 3 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Template name must be at least 3 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			template.Name = name
+		}
+		if request.TemplateType != "" {
+			if _, ok := validLetterTemplateTypes[request.TemplateType]; !ok {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid template type. Allowed values: Offer Letter, Employment Contract"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			template.TemplateType = request.TemplateType
+		}
+		if request.Subject != "" {
+			template.Subject = request.Subject
+		}
+		if request.Body != "" {
+			template.Body = request.Body
+		}
+		if request.IsActive != nil {
+			template.IsActive = *request.IsActive
+		}
+
+		if err := validateLetterTemplate(template.Subject, template.Body); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		template.UpdatedAt = time.Now()
+		if err := db.Save(&template).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update letter template"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Letter template updated successfully",
+			"template": template,
+		})
+	}
+}
+
+func DeleteLetterTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var template models.LetterTemplate
+		if err := db.First(&template, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter template not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Delete(&template)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter template deleted successfully",
+		})
+	}
+}
+
+// CreateOfferLetterByAdmin merender template untuk kandidat atau karyawan dan membuat PDF dengan status Draft
+func CreateOfferLetterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request OfferLetterRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var template models.LetterTemplate
+		if err := db.Where("id = ? AND is_active = ?", request.TemplateID, true).First(&template).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Active letter template not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if (request.CandidateID == 0) == (request.EmployeeID == 0) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Either candidate_id or employee_id is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if template.TemplateType == "Offer Letter" && request.CandidateID == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Offer letters can only be issued to candidates"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		letter := models.OfferLetter{
+			TemplateID:             template.ID,
+			TemplateName:           template.Name,
+			LetterType:             template.TemplateType,
+			Status:                 "Draft",
+			CreatedByAdminID:       adminUser.ID,
+			CreatedByAdminUsername: adminUser.Username,
+		}
+
+		if request.CandidateID != 0 {
+			var candidate models.Candidate
+			if err := db.First(&candidate, request.CandidateID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Candidate not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			if candidate.Status == "Rejected" || (candidate.Status == "Hired" && template.TemplateType == "Offer Letter") {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Candidate is no longer in the recruitment pipeline"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var job models.NewJob
+			db.First(&job, candidate.NewJobID)
+			var designation models.Designation
+			db.First(&designation, job.DesignationID)
+
+			letter.CandidateID = candidate.ID
+			letter.EmployeeID = candidate.EmployeeID
+			letter.RecipientName = strings.TrimSpace(candidate.FirstName + " " + candidate.LastName)
+			letter.RecipientEmail = candidate.Email
+			letter.JobTitle = candidate.JobTitle
+			letter.DesignationName = designation.DesignationName
+			letter.DepartmentName = designation.DepartmentName
+			letter.BasicSalary = candidate.OfferedSalary
+			letter.StartDate = candidate.JoinDate
+		} else {
+			var employee models.Employee
+			if err := db.Where("id = ? AND is_exit = ?", request.EmployeeID, false).First(&employee).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+
+			letter.EmployeeID = employee.ID
+			letter.RecipientName = employee.FullName
+			letter.RecipientEmail = employee.Email
+			letter.JobTitle = employee.Designation
+			letter.DesignationName = employee.Designation
+			letter.DepartmentName = employee.Department
+			letter.BasicSalary = employee.BasicSalary
+		}
+
+		if request.BasicSalary > 0 {
+			letter.BasicSalary = request.BasicSalary
+		}
+		if letter.BasicSalary <= 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Basic salary is required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.StartDate != "" {
+			letter.StartDate = request.StartDate
+		}
+		if _, err := time.Parse("2006-01-02", letter.StartDate); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid start date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		for _, allowance := range request.Allowances {
+			if strings.TrimSpace(allowance.Name) == "" || allowance.Amount <= 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Each allowance requires a name and a positive amount"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			letter.Allowances = append(letter.Allowances, models.OfferLetterAllowance{Name: strings.TrimSpace(allowance.Name), Amount: allowance.Amount})
+			letter.TotalAllowances += allowance.Amount
+		}
+
+		if request.ExpiryDays <= 0 {
+			request.ExpiryDays = 7
+		}
+		currentTime := time.Now()
+		letter.ExpiryDate = currentTime.AddDate(0, 0, request.ExpiryDays).Format("2006-01-02")
+		letter.CreatedAt = &currentTime
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&letter).Error; err != nil {
+				return err
+			}
+
+			// Nomor surat membutuhkan ID sehingga isi surat dirender setelah disimpan
+			letter.LetterNumber = fmt.Sprintf("%s/%s/%04d", validLetterTemplateTypes[letter.LetterType], currentTime.Format("2006/01"), letter.ID)
+			values := offerLetterValues(letter)
+			letter.Subject = renderLetterTemplate(template.Subject, values)
+			letter.Content = renderLetterTemplate(template.Body, values)
+
+			if err := storeOfferLetterPDF(&letter); err != nil {
+				return err
+			}
+			return tx.Omit("Allowances").Save(&letter).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create letter"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Letter created successfully",
+			"letter":  letter,
+		})
+	}
+}
+
+// SendOfferLetterByAdmin mengirim (atau mengirim ulang) surat ke email penerima beserta tautan respon
+func SendOfferLetterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var letter models.OfferLetter
+		if err := db.First(&letter, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if letter.Status != "Draft" && letter.Status != "Sent" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only draft or sent letters can be sent"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if letter.ExpiryDate < time.Now().Format("2006-01-02") {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Letter has passed its expiry date"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		reader, err := fileStorage.Open(letter.DocumentKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter document not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+		pdfData, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to read letter document"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if letter.ResponseToken == "" {
+			letter.ResponseToken = helper.GenerateUniqueToken()
+		}
+
+		err = helper.SendOfferLetterEmail(letter.RecipientEmail, letter.RecipientName, letter.Subject, letter.LetterType, offerLetterResponseURL(letter.ResponseToken), formatLetterDate(letter.ExpiryDate), pdfData, letter.DocumentName)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to send letter email"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		currentTime := time.Now()
+		letter.Status = "Sent"
+		letter.SentAt = &currentTime
+		letter.UpdatedAt = currentTime
+		db.Save(&letter)
+
+		// Kandidat yang menerima surat penawaran dipindahkan ke tahap Offer tanpa email tambahan
+		if letter.LetterType == "Offer Letter" && letter.CandidateID != 0 {
+			var candidate models.Candidate
+			if err := db.First(&candidate, letter.CandidateID).Error; err == nil && candidate.Status == "Active" && candidate.StageType != "Offer" {
+				var offerStage models.RecruitmentStage
+				if err := db.Where("stage_type = ?", "Offer").Order("sort_order ASC").First(&offerStage).Error; err == nil {
+					if err := transitionCandidate(db, &candidate, offerStage, "Offer letter "+letter.LetterNumber+" sent", "", false, adminUser); err != nil {
+						fmt.Println("Failed to move candidate to offer stage:", err)
+					}
+				}
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter sent successfully",
+			"letter":  letter,
+		})
+	}
+}
+
+func GetAllOfferLettersByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		expireOfferLetters(db)
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.OfferLetter{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("recipient_name ILIKE ? OR letter_number ILIKE ? OR job_title ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+		if letterType := c.QueryParam("letter_type"); letterType != "" {
+			query = query.Where("letter_type = ?", letterType)
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+		if candidateID := c.QueryParam("candidate_id"); candidateID != "" {
+			query = query.Where("candidate_id = ?", candidateID)
+		}
+		if employeeID := c.QueryParam("employee_id"); employeeID != "" {
+			query = query.Where("employee_id = ?", employeeID)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var letters []models.OfferLetter
+		if err := query.Preload("Allowances").Order("id DESC").Offset(offset).Limit(perPage).Find(&letters).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching letters"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letters retrieved successfully",
+			"letters": letters,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetOfferLetterByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		expireOfferLetters(db)
+
+		var letter models.OfferLetter
+		if err := db.Preload("Allowances").First(&letter, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter retrieved successfully",
+			"letter":  letter,
+		})
+	}
+}
+
+func DownloadOfferLetterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var letter models.OfferLetter
+		if err := db.First(&letter, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamOfferLetterPDF(c, letter)
+	}
+}
+
+// WithdrawOfferLetterByAdmin menarik surat yang belum direspon sehingga tautan respon tidak berlaku lagi
+func WithdrawOfferLetterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var letter models.OfferLetter
+		if err := db.First(&letter, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if letter.Status != "Draft" && letter.Status != "Sent" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only draft or sent letters can be withdrawn"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		letter.Status = "Withdrawn"
+		letter.UpdatedAt = time.Now()
+		db.Save(&letter)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter withdrawn successfully",
+			"letter":  letter,
+		})
+	}
+}
+
+func DeleteOfferLetterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var letter models.OfferLetter
+		if err := db.First(&letter, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Surat yang sudah direspon disimpan sebagai bukti persetujuan
+		if letter.Status == "Accepted" || letter.Status == "Declined" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Letters that have been responded to cannot be deleted"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if fileStorage, err := helper.NewFileStorage(); err == nil && letter.DocumentKey != "" {
+			if err := fileStorage.Delete(letter.DocumentKey); err != nil {
+				fmt.Println("Failed to delete letter document:", err)
+			}
+		}
+
+		db.Where("offer_letter_id = ?", letter.ID).Delete(&models.OfferLetterAllowance{})
+		db.Delete(&letter)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter deleted successfully",
+		})
+	}
+}
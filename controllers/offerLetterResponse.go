@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type OfferLetterResponseRequest struct {
+	Decision string `json:"decision"`  // accept atau decline
+	FullName string `json:"full_name"` // Tanda tangan elektronik, harus sama dengan nama penerima
+	Agree    bool   `json:"agree"`
+	Reason   string `json:"reason"`
+}
+
+// offerLetterByToken mengambil surat dari token pada tautan email
+func offerLetterByToken(db *gorm.DB, token string) (models.OfferLetter, bool) {
+	var letter models.OfferLetter
+	if token == "" {
+		return letter, false
+	}
+
+	expireOfferLetters(db)
+	err := db.Preload("Allowances").Where("response_token = ? AND status <> ?", token, "Draft").First(&letter).Error
+	return letter, err == nil
+}
+
+// GetOfferLetterByToken menampilkan isi surat kepada penerima melalui tautan pada email
+func GetOfferLetterByToken(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		letter, ok := offerLetterByToken(db, c.Param("token"))
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Letter retrieved successfully",
+			"data": map[string]interface{}{
+				"letter_number":    letter.LetterNumber,
+				"letter_type":      letter.LetterType,
+				"recipient_name":   letter.RecipientName,
+				"subject":          letter.Subject,
+				"content":          letter.Content,
+				"basic_salary":     letter.BasicSalary,
+				"allowances":       letter.Allowances,
+				"total_allowances": letter.TotalAllowances,
+				"start_date":       letter.StartDate,
+				"expiry_date":      letter.ExpiryDate,
+				"status":           letter.Status,
+				"responded_at":     letter.RespondedAt,
+				"acknowledged_at":  letter.AcknowledgedAt,
+			},
+		})
+	}
+}
+
+func DownloadOfferLetterByToken(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		letter, ok := offerLetterByToken(db, c.Param("token"))
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamOfferLetterPDF(c, letter)
+	}
+}
+
+// RespondOfferLetterByToken mencatat keputusan penerima; persetujuan menyimpan nama, waktu, IP dan user agent sebagai e-acknowledgement
+func RespondOfferLetterByToken(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		letter, ok := offerLetterByToken(db, c.Param("token"))
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Letter not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		switch letter.Status {
+		case "Accepted", "Declined":
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Letter has already been " + strings.ToLower(letter.Status)}
+			return c.JSON(http.StatusConflict, errorResponse)
+		case "Expired", "Withdrawn":
+			errorResponse := helper.ErrorResponse{Code: http.StatusGone, Message: "Letter is no longer valid"}
+			return c.JSON(http.StatusGone, errorResponse)
+		}
+
+		var request OfferLetterResponseRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		switch request.Decision {
+		case "accept":
+			if !request.Agree {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "You must agree to the terms of the letter"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if !strings.EqualFold(strings.Join(strings.Fields(request.FullName), " "), strings.Join(strings.Fields(letter.RecipientName), " ")) {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Full name must match the name on the letter"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			letter.Status = "Accepted"
+			letter.AcknowledgedAt = &currentTime
+			letter.AcknowledgedName = strings.Join(strings.Fields(request.FullName), " ")
+			letter.AcknowledgedIP = c.RealIP()
+			letter.AcknowledgedUserAgent = c.Request().UserAgent()
+		case "decline":
+			if len(request.Reason) > 1000 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Reason must not exceed 1000 characters"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			letter.Status = "Declined"
+			letter.DeclineReason = request.Reason
+		default:
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid decision. Allowed values: accept, decline"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		letter.RespondedAt = &currentTime
+		letter.UpdatedAt = currentTime
+
+		// PDF dibuat ulang dengan cap persetujuan elektronik
+		if letter.Status == "Accepted" {
+			if err := storeOfferLetterPDF(&letter); err != nil {
+				fmt.Println("Failed to regenerate accepted letter:", err)
+			}
+		}
+
+		if err := db.Omit("Allowances").Save(&letter).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to record response"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if letter.CandidateID != 0 {
+			note := fmt.Sprintf("%s %s %s by candidate", letter.LetterType, letter.LetterNumber, strings.ToLower(letter.Status))
+			if letter.DeclineReason != "" {
+				note += ": " + letter.DeclineReason
+			}
+
+			var candidate models.Candidate
+			if err := db.First(&candidate, letter.CandidateID).Error; err == nil {
+				history := models.CandidateStageHistory{
+					CandidateID:   candidate.ID,
+					FromStageID:   candidate.StageID,
+					FromStageName: candidate.StageName,
+					ToStageID:     candidate.StageID,
+					ToStageName:   candidate.StageName,
+					Note:          note,
+					CreatedAt:     &currentTime,
+				}
+				db.Create(&history)
+
+				// Gaji dan tanggal mulai yang disetujui dipakai saat kandidat direkrut
+				if letter.Status == "Accepted" && letter.LetterType == "Offer Letter" {
+					db.Model(&candidate).Updates(map[string]interface{}{
+						"offered_salary": letter.BasicSalary,
+						"join_date":      letter.StartDate,
+						"updated_at":     currentTime,
+					})
+				}
+			}
+		}
+
+		var admins []models.Admin
+		db.Where("is_admin_hr = ?", true).Find(&admins)
+		for _, admin := range admins {
+			err := helper.SendOfferLetterResponseNotification(admin.Email, admin.FirstName+" "+admin.LastName, letter.RecipientName, letter.LetterType, letter.LetterNumber, letter.Status, letter.DeclineReason)
+			if err != nil {
+				fmt.Println("Failed to send letter response notification:", err)
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":            http.StatusOK,
+			"error":           false,
+			"message":         "Letter " + strings.ToLower(letter.Status) + " successfully",
+			"status":          letter.Status,
+			"acknowledged_at": letter.AcknowledgedAt,
+		})
+	}
+}
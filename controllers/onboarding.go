@@ -0,0 +1,867 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var validOnboardingAssigneeRoles = map[string]bool{
+	"HR":      true,
+	"IT":      true,
+	"Manager": true,
+}
+
+// Kategori IT Account, Contract, BPJS, Bank Details dan Policy diselesaikan otomatis jika datanya sudah lengkap
+var validOnboardingCategories = map[string]bool{
+	"IT Account":   true,
+	"Contract":     true,
+	"BPJS":         true,
+	"Bank Details": true,
+	"Policy":       true,
+	"Orientation":  true,
+	"Other":        true,
+}
+
+// defaultOnboardingTasks dipakai untuk template "Standard Onboarding" jika belum ada template
+var defaultOnboardingTasks = []models.OnboardingTemplateTask{
+	{Title: "Create IT account", Description: "Buat email perusahaan, akun sistem internal dan siapkan perangkat kerja.", Category: "IT Account", AssigneeRole: "IT", DueDays: 0, SortOrder: 1},
+	{Title: "Sign employment contract", Description: "Tanda tangani kontrak kerja dan unggah dokumennya pada menu kontrak karyawan.", Category: "Contract", AssigneeRole: "HR", DueDays: 0, SortOrder: 2},
+	{Title: "Register BPJS Kesehatan and Ketenagakerjaan", Description: "Daftarkan karyawan ke BPJS Kesehatan dan BPJS Ketenagakerjaan, lalu isi nomor BPJS pada profil karyawan.", Category: "BPJS", AssigneeRole: "HR", DueDays: 30, SortOrder: 3},
+	{Title: "Collect bank account details", Description: "Lengkapi nama bank dan nomor rekening karyawan untuk keperluan payroll.", Category: "Bank Details", AssigneeRole: "HR", DueDays: 7, SortOrder: 4},
+	{Title: "Acknowledge company policies", Description: "Pastikan karyawan telah membaca dan menyetujui seluruh kebijakan perusahaan.", Category: "Policy", AssigneeRole: "HR", DueDays: 7, SortOrder: 5},
+	{Title: "Team introduction and probation objectives", Description: "Perkenalkan karyawan kepada tim dan tetapkan target selama masa percobaan.", Category: "Orientation", AssigneeRole: "Manager", DueDays: 7, SortOrder: 6},
+}
+
+type OnboardingTemplateTaskRequest struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Category     string `json:"category"`
+	AssigneeRole string `json:"assignee_role"`
+	DueDays      int    `json:"due_days"`
+}
+
+type OnboardingTemplateRequest struct {
+	Name        string                          `json:"name"`
+	Description string                          `json:"description"`
+	IsDefault   bool                            `json:"is_default"`
+	Tasks       []OnboardingTemplateTaskRequest `json:"tasks"`
+}
+
+type OnboardingTaskUpdateRequest struct {
+	Status             string `json:"status"` // Completed atau Pending
+	Note               string `json:"note"`
+	AssigneeEmployeeID uint   `json:"assignee_employee_id"`
+}
+
+// ensureDefaultOnboardingTemplate membuat template standar jika belum ada template sama sekali
+func ensureDefaultOnboardingTemplate(db *gorm.DB) {
+	var count int64
+	db.Model(&models.OnboardingTemplate{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	currentTime := time.Now()
+	template := models.OnboardingTemplate{
+		Name:        "Standard Onboarding",
+		Description: "Checklist standar untuk karyawan baru",
+		IsDefault:   true,
+		Tasks:       append([]models.OnboardingTemplateTask{}, defaultOnboardingTasks...),
+		CreatedAt:   &currentTime,
+	}
+	db.Create(&template)
+}
+
+// onboardingTemplateByID mengambil template beserta tugasnya, ID 0 berarti template default
+func onboardingTemplateByID(db *gorm.DB, templateID uint) (models.OnboardingTemplate, error) {
+	ensureDefaultOnboardingTemplate(db)
+
+	var template models.OnboardingTemplate
+	query := db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC") })
+	if templateID != 0 {
+		err := query.First(&template, templateID).Error
+		return template, err
+	}
+
+	err := query.Order("is_default DESC").Order("id ASC").First(&template).Error
+	return template, err
+}
+
+// validateOnboardingTemplateRequest mengubah request menjadi daftar tugas template
+func validateOnboardingTemplateRequest(request OnboardingTemplateRequest) ([]models.OnboardingTemplateTask, error) {
+	if len(strings.TrimSpace(request.Name)) < 3 || len(request.Name) > 100 {
+		return nil, errors.New("Template name must be between 3 and 100 characters")
+	}
+	if len(request.Tasks) == 0 {
+		return nil, errors.New("Template must have at least one task")
+	}
+
+	var tasks []models.OnboardingTemplateTask
+	for i, task := range request.Tasks {
+		if strings.TrimSpace(task.Title) == "" {
+			return nil, fmt.Errorf("Task %d: title is required", i+1)
+		}
+		if !validOnboardingAssigneeRoles[task.AssigneeRole] {
+			return nil, fmt.Errorf("Task %d: assignee role must be HR, IT or Manager", i+1)
+		}
+		if task.Category == "" {
+			task.Category = "Other"
+		}
+		if !validOnboardingCategories[task.Category] {
+			return nil, fmt.Errorf("Task %d: invalid category", i+1)
+		}
+
+		tasks = append(tasks, models.OnboardingTemplateTask{
+			Title:        strings.TrimSpace(task.Title),
+			Description:  task.Description,
+			Category:     task.Category,
+			AssigneeRole: task.AssigneeRole,
+			DueDays:      task.DueDays,
+			SortOrder:    i + 1,
+		})
+	}
+
+	return tasks, nil
+}
+
+// itOnboardingAssignee adalah kepala departemen IT, tugas IT dikerjakan HR jika departemen IT tidak ditemukan
+func itOnboardingAssignee(db *gorm.DB) (models.Employee, bool) {
+	var department models.Department
+	if err := db.Where("LOWER(department_name) IN ?", []string{"it", "information technology", "teknologi informasi"}).First(&department).Error; err != nil || department.EmployeeID == 0 {
+		return models.Employee{}, false
+	}
+
+	var employee models.Employee
+	if err := db.Where("id = ? AND is_exit = ?", department.EmployeeID, false).First(&employee).Error; err != nil {
+		return models.Employee{}, false
+	}
+	return employee, true
+}
+
+// createOnboardingChecklist menyalin tugas template menjadi checklist karyawan baru
+func createOnboardingChecklist(tx *gorm.DB, employee models.Employee, candidateID uint, template models.OnboardingTemplate, manager, itEmployee *models.Employee, joinDate time.Time) (models.OnboardingChecklist, error) {
+	currentTime := time.Now()
+	checklist := models.OnboardingChecklist{
+		EmployeeID:   employee.ID,
+		FullName:     employee.FullName,
+		CandidateID:  candidateID,
+		TemplateID:   template.ID,
+		TemplateName: template.Name,
+		JoinDate:     joinDate.Format("2006-01-02"),
+		Status:       "In Progress",
+		CreatedAt:    &currentTime,
+	}
+	if manager != nil {
+		checklist.ManagerID = manager.ID
+		checklist.ManagerName = manager.FullName
+	}
+
+	for _, templateTask := range template.Tasks {
+		task := models.OnboardingTask{
+			EmployeeID:   employee.ID,
+			Title:        templateTask.Title,
+			Description:  templateTask.Description,
+			Category:     templateTask.Category,
+			AssigneeRole: templateTask.AssigneeRole,
+			AssigneeName: "HR",
+			DueDate:      joinDate.AddDate(0, 0, templateTask.DueDays).Format("2006-01-02"),
+			SortOrder:    templateTask.SortOrder,
+			Status:       "Pending",
+		}
+
+		switch {
+		case templateTask.AssigneeRole == "Manager" && manager != nil:
+			task.AssigneeEmployeeID = manager.ID
+			task.AssigneeName = manager.FullName
+		case templateTask.AssigneeRole == "IT" && itEmployee != nil:
+			task.AssigneeEmployeeID = itEmployee.ID
+			task.AssigneeName = itEmployee.FullName
+		}
+
+		checklist.Tasks = append(checklist.Tasks, task)
+	}
+
+	err := tx.Create(&checklist).Error
+	return checklist, err
+}
+
+// notifyOnboardingAssignees mengirim email ke karyawan yang ditugaskan (IT dan Manager)
+func notifyOnboardingAssignees(db *gorm.DB, checklist models.OnboardingChecklist) {
+	for _, task := range checklist.Tasks {
+		if task.AssigneeEmployeeID == 0 {
+			continue
+		}
+
+		var assignee models.Employee
+		if err := db.First(&assignee, task.AssigneeEmployeeID).Error; err != nil || assignee.Email == "" {
+			continue
+		}
+
+		if err := helper.SendOnboardingTaskNotification(assignee.Email, assignee.FullName, checklist.FullName, task.Title, task.DueDate); err != nil {
+			fmt.Println("Failed to send onboarding task notification:", err)
+		}
+	}
+}
+
+// pendingPolicyCount menghitung kebijakan versi aktif yang belum disetujui karyawan
+func pendingPolicyCount(db *gorm.DB, employeeID uint) int {
+	var policies []models.Policy
+	db.Find(&policies)
+
+	var acknowledgedVersionIDs []uint
+	db.Model(&models.PolicyAcknowledgement{}).Where("employee_id = ?", employeeID).Pluck("policy_version_id", &acknowledgedVersionIDs)
+	acknowledged := map[uint]bool{}
+	for _, id := range acknowledgedVersionIDs {
+		acknowledged[id] = true
+	}
+
+	pending := 0
+	for i := range policies {
+		if version, ok := currentPolicyVersion(db, &policies[i]); ok && !acknowledged[version.ID] {
+			pending++
+		}
+	}
+	return pending
+}
+
+// syncOnboardingAutoTasks menyelesaikan tugas yang datanya sudah lengkap di profil karyawan
+func syncOnboardingAutoTasks(db *gorm.DB, checklist *models.OnboardingChecklist) {
+	if checklist.Status == "Completed" {
+		return
+	}
+
+	var employee models.Employee
+	if err := db.First(&employee, checklist.EmployeeID).Error; err != nil {
+		return
+	}
+
+	changed := false
+	for i := range checklist.Tasks {
+		task := &checklist.Tasks[i]
+		if task.Status == "Completed" {
+			continue
+		}
+
+		done := false
+		switch task.Category {
+		case "Bank Details":
+			done = employee.BankName != "" && employee.AccountNumber != ""
+		case "BPJS":
+			done = employee.BpjsKesehatan != ""
+		case "Policy":
+			done = pendingPolicyCount(db, employee.ID) == 0
+		case "Contract":
+			var contractCount int64
+			db.Model(&models.EmploymentContract{}).Where("employee_id = ? AND document_key <> ?", employee.ID, "").Count(&contractCount)
+			done = contractCount > 0
+		}
+
+		if done {
+			if err := completeOnboardingTask(db, task, "System", 0, "System", "Completed automatically from employee data"); err == nil {
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		refreshOnboardingChecklistStatus(db, checklist)
+	}
+}
+
+func completeOnboardingTask(db *gorm.DB, task *models.OnboardingTask, role string, completedByID uint, completedByName, note string) error {
+	currentTime := time.Now()
+	task.Status = "Completed"
+	task.CompletedByRole = role
+	task.CompletedByID = completedByID
+	task.CompletedByName = completedByName
+	task.CompletedAt = &currentTime
+	if note != "" {
+		task.Note = note
+	}
+	return db.Save(task).Error
+}
+
+// refreshOnboardingChecklistStatus menandai checklist selesai jika semua tugas selesai (dan sebaliknya)
+func refreshOnboardingChecklistStatus(db *gorm.DB, checklist *models.OnboardingChecklist) {
+	var pending int64
+	db.Model(&models.OnboardingTask{}).Where("checklist_id = ? AND status <> ?", checklist.ID, "Completed").Count(&pending)
+
+	status := "In Progress"
+	var completedAt *time.Time
+	if pending == 0 {
+		currentTime := time.Now()
+		status = "Completed"
+		completedAt = &currentTime
+	}
+	if status == checklist.Status {
+		return
+	}
+
+	checklist.Status = status
+	checklist.CompletedAt = completedAt
+	checklist.UpdatedAt = time.Now()
+	db.Model(&models.OnboardingChecklist{}).Where("id = ?", checklist.ID).Updates(map[string]interface{}{
+		"status":       checklist.Status,
+		"completed_at": checklist.CompletedAt,
+		"updated_at":   checklist.UpdatedAt,
+	})
+}
+
+func onboardingProgress(checklist models.OnboardingChecklist) map[string]interface{} {
+	today := time.Now().Format("2006-01-02")
+	completed, overdue := 0, 0
+	for _, task := range checklist.Tasks {
+		if task.Status == "Completed" {
+			completed++
+		} else if task.DueDate < today {
+			overdue++
+		}
+	}
+
+	percentage := 0.0
+	if len(checklist.Tasks) > 0 {
+		percentage = math.Round(float64(completed)/float64(len(checklist.Tasks))*10000) / 100
+	}
+
+	return map[string]interface{}{
+		"total_tasks":     len(checklist.Tasks),
+		"completed_tasks": completed,
+		"overdue_tasks":   overdue,
+		"percentage":      percentage,
+	}
+}
+
+func preloadOnboardingTasks(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC").Order("id ASC") })
+}
+
+func GetOnboardingTemplatesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		ensureDefaultOnboardingTemplate(db)
+
+		var templates []models.OnboardingTemplate
+		db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC") }).Order("id ASC").Find(&templates)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Onboarding templates retrieved successfully",
+			"templates": templates,
+		})
+	}
+}
+
+func CreateOnboardingTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request OnboardingTemplateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		tasks, err := validateOnboardingTemplateRequest(request)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		ensureDefaultOnboardingTemplate(db)
+
+		currentTime := time.Now()
+		template := models.OnboardingTemplate{
+			Name:        strings.TrimSpace(request.Name),
+			Description: request.Description,
+			IsDefault:   request.IsDefault,
+			Tasks:       tasks,
+			CreatedAt:   &currentTime,
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if template.IsDefault {
+				if err := tx.Model(&models.OnboardingTemplate{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Create(&template).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create onboarding template"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":     http.StatusCreated,
+			"error":    false,
+			"message":  "Onboarding template created successfully",
+			"template": template,
+		})
+	}
+}
+
+// UpdateOnboardingTemplateByAdmin mengganti seluruh tugas template, checklist yang sudah dibuat tidak berubah
+func UpdateOnboardingTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var template models.OnboardingTemplate
+		if err := db.First(&template, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding template not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request OnboardingTemplateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		tasks, err := validateOnboardingTemplateRequest(request)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if template.IsDefault && !request.IsDefault {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Set another template as default instead of unsetting the default template"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		template.Name = strings.TrimSpace(request.Name)
+		template.Description = request.Description
+		template.IsDefault = request.IsDefault
+		template.UpdatedAt = time.Now()
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if template.IsDefault {
+				if err := tx.Model(&models.OnboardingTemplate{}).Where("is_default = ? AND id <> ?", true, template.ID).Update("is_default", false).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Where("template_id = ?", template.ID).Delete(&models.OnboardingTemplateTask{}).Error; err != nil {
+				return err
+			}
+			for i := range tasks {
+				tasks[i].TemplateID = template.ID
+			}
+			if err := tx.Create(&tasks).Error; err != nil {
+				return err
+			}
+			return tx.Save(&template).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update onboarding template"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		template.Tasks = tasks
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Onboarding template updated successfully",
+			"template": template,
+		})
+	}
+}
+
+func DeleteOnboardingTemplateByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var template models.OnboardingTemplate
+		if err := db.First(&template, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding template not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if template.IsDefault {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Default onboarding template cannot be deleted"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		db.Where("template_id = ?", template.ID).Delete(&models.OnboardingTemplateTask{})
+		db.Delete(&template)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Onboarding template deleted successfully",
+		})
+	}
+}
+
+func GetAllOnboardingChecklistsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.OnboardingChecklist{})
+		if searching := c.QueryParam("searching"); searching != "" {
+			query = query.Where("full_name ILIKE ?", "%"+searching+"%")
+		}
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var checklists []models.OnboardingChecklist
+		if err := preloadOnboardingTasks(query).Order("id DESC").Offset(offset).Limit(perPage).Find(&checklists).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Error fetching onboarding checklists"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var response []map[string]interface{}
+		for i := range checklists {
+			syncOnboardingAutoTasks(db, &checklists[i])
+			response = append(response, map[string]interface{}{
+				"checklist": checklists[i],
+				"progress":  onboardingProgress(checklists[i]),
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Onboarding checklists retrieved successfully",
+			"checklists": response,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func GetOnboardingChecklistByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var checklist models.OnboardingChecklist
+		if err := preloadOnboardingTasks(db).First(&checklist, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding checklist not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		syncOnboardingAutoTasks(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Onboarding checklist retrieved successfully",
+			"checklist": checklist,
+			"progress":  onboardingProgress(checklist),
+		})
+	}
+}
+
+// UpdateOnboardingTaskByAdmin menyelesaikan, membuka kembali atau mengalihkan tugas onboarding
+func UpdateOnboardingTaskByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var task models.OnboardingTask
+		if err := db.First(&task, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding task not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request OnboardingTaskUpdateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.Status != "" && request.Status != "Completed" && request.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid status. Allowed values: Completed, Pending"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var checklist models.OnboardingChecklist
+		db.First(&checklist, task.ChecklistID)
+
+		if request.AssigneeEmployeeID != 0 && request.AssigneeEmployeeID != task.AssigneeEmployeeID {
+			var assignee models.Employee
+			if err := db.Where("id = ? AND is_client = ? AND is_exit = ?", request.AssigneeEmployeeID, false, false).First(&assignee).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Assignee must be an active employee"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			task.AssigneeEmployeeID = assignee.ID
+			task.AssigneeName = assignee.FullName
+
+			if assignee.Email != "" {
+				if err := helper.SendOnboardingTaskNotification(assignee.Email, assignee.FullName, checklist.FullName, task.Title, task.DueDate); err != nil {
+					fmt.Println("Failed to send onboarding task notification:", err)
+				}
+			}
+		}
+
+		if request.Note != "" {
+			task.Note = request.Note
+		}
+
+		switch {
+		case request.Status == "Completed" && task.Status != "Completed":
+			err = completeOnboardingTask(db, &task, "Admin", adminUser.ID, adminUser.Username, request.Note)
+		case request.Status == "Pending" && task.Status == "Completed":
+			task.Status = "Pending"
+			task.CompletedByRole = ""
+			task.CompletedByID = 0
+			task.CompletedByName = ""
+			task.CompletedAt = nil
+			err = db.Save(&task).Error
+		default:
+			err = db.Save(&task).Error
+		}
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update onboarding task"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		refreshOnboardingChecklistStatus(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":             http.StatusOK,
+			"error":            false,
+			"message":          "Onboarding task updated successfully",
+			"task":             task,
+			"checklist_status": checklist.Status,
+		})
+	}
+}
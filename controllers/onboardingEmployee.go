@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetOnboardingByEmployee menampilkan checklist onboarding milik karyawan yang login
+func GetOnboardingByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var checklist models.OnboardingChecklist
+		if err := preloadOnboardingTasks(db).Where("employee_id = ?", employee.ID).Order("id DESC").First(&checklist).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding checklist not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		syncOnboardingAutoTasks(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Onboarding checklist retrieved successfully",
+			"checklist": checklist,
+			"progress":  onboardingProgress(checklist),
+		})
+	}
+}
+
+// GetOnboardingTasksByEmployee menampilkan tugas onboarding karyawan lain yang ditugaskan ke karyawan yang login (IT/Manager)
+func GetOnboardingTasksByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.OnboardingTask{}).Where("assignee_employee_id = ?", employee.ID)
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var tasks []models.OnboardingTask
+		query.Order("status DESC").Order("due_date ASC").Offset(offset).Limit(perPage).Find(&tasks)
+
+		// Nama karyawan baru diambil dari checklist
+		var checklistIDs []uint
+		for _, task := range tasks {
+			checklistIDs = append(checklistIDs, task.ChecklistID)
+		}
+		checklistNames := map[uint]string{}
+		if len(checklistIDs) > 0 {
+			var checklists []models.OnboardingChecklist
+			db.Where("id IN ?", checklistIDs).Find(&checklists)
+			for _, checklist := range checklists {
+				checklistNames[checklist.ID] = checklist.FullName
+			}
+		}
+
+		var response []map[string]interface{}
+		for _, task := range tasks {
+			response = append(response, map[string]interface{}{
+				"task":              task,
+				"new_employee_name": checklistNames[task.ChecklistID],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Onboarding tasks retrieved successfully",
+			"tasks":   response,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func CompleteOnboardingTaskByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var task models.OnboardingTask
+		if err := db.First(&task, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Onboarding task not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if task.AssigneeEmployeeID != employee.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Onboarding task is not assigned to the employee"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		if task.Status == "Completed" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Onboarding task is already completed"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request OnboardingTaskUpdateRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if err := completeOnboardingTask(db, &task, "Employee", employee.ID, employee.FullName, request.Note); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to complete onboarding task"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var checklist models.OnboardingChecklist
+		db.First(&checklist, task.ChecklistID)
+		refreshOnboardingChecklistStatus(db, &checklist)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":             http.StatusOK,
+			"error":            false,
+			"message":          "Onboarding task completed successfully",
+			"task":             task,
+			"checklist_status": checklist.Status,
+		})
+	}
+}
@@ -69,6 +69,11 @@ func CreateOvertimeRequestByEmployee(db *gorm.DB, secretKey []byte) echo.Handler
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		if err := validateOvertimeAgainstAttendance(db, employee, overtime, 0); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
 		inTime, err := time.Parse("15:04", overtime.InTime)
 		if err != nil {
 			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid in_time format. Required format: HH:mm"}
@@ -443,6 +448,13 @@ func UpdateOvertimeRequestByIDByEmployee(db *gorm.DB, secretKey []byte) echo.Han
 			overtimeRequest.Reason = updatedOvertimeRequest.Reason
 		}
 
+		if updatedOvertimeRequest.Date != "" || updatedOvertimeRequest.InTime != "" || updatedOvertimeRequest.OutTime != "" {
+			if err := validateOvertimeAgainstAttendance(db, employee, overtimeRequest, overtimeRequest.ID); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: err.Error()}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
 		if updatedOvertimeRequest.InTime != "" || updatedOvertimeRequest.OutTime != "" {
 			inTime, _ := time.Parse("15:04", overtimeRequest.InTime)
 			outTime, _ := time.Parse("15:04", overtimeRequest.OutTime)
@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Batas lembur 14 jam per minggu sesuai ketentuan lembur di Indonesia
+const maxWeeklyOvertimeMinutes = 14 * 60
+
+// overtimeSuggestionMinutes membaca env OVERTIME_SUGGESTION_MINUTES (default 30).
+// Saran lembur otomatis dimatikan jika nilainya 0.
+func overtimeSuggestionMinutes() int {
+	value := os.Getenv("OVERTIME_SUGGESTION_MINUTES")
+	if value == "" {
+		return 30
+	}
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		return 30
+	}
+	return minutes
+}
+
+// overtimeWeekRange mengembalikan tanggal Senin dan Minggu dari minggu tanggal lembur
+func overtimeWeekRange(date time.Time) (string, string) {
+	offsetToMonday := (int(date.Weekday()) + 6) % 7
+	monday := date.AddDate(0, 0, -offsetToMonday)
+	return monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02")
+}
+
+// validateOvertimeAgainstAttendance memastikan lembur berada setelah jam akhir shift dan sebelum checkout
+// yang tercatat, serta total lembur minggu tersebut (Pending dan Accepted) tidak melebihi 14 jam.
+// excludeID diisi ID lembur yang sedang diubah agar tidak terhitung dua kali.
+func validateOvertimeAgainstAttendance(db *gorm.DB, employee models.Employee, overtime models.OvertimeRequest, excludeID uint) error {
+	overtimeDate, err := time.Parse("2006-01-02", overtime.Date)
+	if err != nil {
+		return errors.New("Invalid Overtime Request date format. Required format: yyyy-mm-dd")
+	}
+
+	inTime, err := time.Parse("15:04", overtime.InTime)
+	if err != nil {
+		return errors.New("Invalid in_time format. Required format: HH:mm")
+	}
+	outTime, err := time.Parse("15:04", overtime.OutTime)
+	if err != nil {
+		return errors.New("Invalid out_time format. Required format: HH:mm")
+	}
+	if !outTime.After(inTime) {
+		return errors.New("Overtime out_time must be after in_time")
+	}
+
+	var attendance models.Attendance
+	result := db.Where("employee_id = ? AND attendance_date = ?", employee.ID, overtime.Date).First(&attendance)
+	if result.Error != nil || attendance.InTime == "" || attendance.OutTime == "" {
+		return errors.New("No completed attendance found for the overtime date")
+	}
+
+	checkOut, err := time.Parse("15:04:05", attendance.OutTime)
+	if err != nil {
+		return errors.New("Invalid attendance check-out time")
+	}
+	if outTime.Hour()*60+outTime.Minute() > checkOut.Hour()*60+checkOut.Minute() {
+		return fmt.Errorf("Overtime must end before the recorded check-out at %s", checkOut.Format("15:04"))
+	}
+
+	_, shiftOutTime, err := getShiftForDay(db, getEffectiveShiftID(db, employee, overtime.Date), overtimeDate.Weekday().String())
+	if err != nil {
+		return errors.New("Failed to fetch shift data")
+	}
+
+	// Hari istirahat (tanpa jam shift) boleh lembur selama masih dalam jam absensi
+	if shiftOutTime == "" {
+		checkIn, err := time.Parse("15:04:05", attendance.InTime)
+		if err != nil {
+			return errors.New("Invalid attendance check-in time")
+		}
+		if inTime.Hour()*60+inTime.Minute() < checkIn.Hour()*60+checkIn.Minute() {
+			return fmt.Errorf("Overtime must start after the recorded check-in at %s", checkIn.Format("15:04"))
+		}
+	} else {
+		shiftOut, err := time.Parse("15:04:05", shiftOutTime)
+		if err != nil {
+			return errors.New("Invalid shift out time")
+		}
+		if inTime.Hour()*60+inTime.Minute() < shiftOut.Hour()*60+shiftOut.Minute() {
+			return fmt.Errorf("Overtime must start after the shift ends at %s", shiftOut.Format("15:04"))
+		}
+	}
+
+	// Durasi dihitung dari in_time/out_time karena total_minutes direset ke 0 setiap payroll dijalankan
+	weekStart, weekEnd := overtimeWeekRange(overtimeDate)
+	var weeklyOvertimes []models.OvertimeRequest
+	db.Where("employee_id = ? AND date BETWEEN ? AND ? AND status IN ? AND id <> ?", employee.ID, weekStart, weekEnd, []string{"Pending", "Accepted"}, excludeID).
+		Find(&weeklyOvertimes)
+	var weeklyMinutes int64
+	for _, weeklyOvertime := range weeklyOvertimes {
+		weeklyMinutes += overtimeDurationMinutes(weeklyOvertime)
+	}
+
+	requestedMinutes := int64(outTime.Sub(inTime).Minutes())
+	if weeklyMinutes+requestedMinutes > maxWeeklyOvertimeMinutes {
+		return fmt.Errorf("Weekly overtime cap of 14 hours exceeded. Remaining for the week of %s: %d minutes", weekStart, maxOvertimeRemaining(weeklyMinutes))
+	}
+
+	return nil
+}
+
+// overtimeDurationMinutes menghitung durasi lembur dari jam mulai dan selesai (HH:mm atau HH:mm:ss)
+func overtimeDurationMinutes(overtime models.OvertimeRequest) int64 {
+	parse := func(value string) (time.Time, error) {
+		if parsed, err := time.Parse("15:04", value); err == nil {
+			return parsed, nil
+		}
+		return time.Parse("15:04:05", value)
+	}
+
+	inTime, err := parse(overtime.InTime)
+	if err != nil {
+		return 0
+	}
+	outTime, err := parse(overtime.OutTime)
+	if err != nil || !outTime.After(inTime) {
+		return 0
+	}
+	return int64(outTime.Sub(inTime).Minutes())
+}
+
+func maxOvertimeRemaining(usedMinutes int64) int64 {
+	if usedMinutes >= maxWeeklyOvertimeMinutes {
+		return 0
+	}
+	return maxWeeklyOvertimeMinutes - usedMinutes
+}
+
+// suggestOvertime menyarankan lembur jika checkout melewati jam akhir shift lebih dari ambang menit
+func suggestOvertime(db *gorm.DB, employee models.Employee, attendance models.Attendance) (map[string]interface{}, bool) {
+	threshold := overtimeSuggestionMinutes()
+	if threshold == 0 || attendance.OutTime == "" {
+		return nil, false
+	}
+
+	attendanceDate, err := time.Parse("2006-01-02", attendance.AttendanceDate)
+	if err != nil {
+		return nil, false
+	}
+
+	_, shiftOutTime, err := getShiftForDay(db, getEffectiveShiftID(db, employee, attendance.AttendanceDate), attendanceDate.Weekday().String())
+	if err != nil || shiftOutTime == "" {
+		return nil, false
+	}
+
+	shiftOut, err := time.Parse("15:04:05", shiftOutTime)
+	if err != nil {
+		return nil, false
+	}
+	checkOut, err := time.Parse("15:04:05", attendance.OutTime)
+	if err != nil {
+		return nil, false
+	}
+
+	extraMinutes := int(checkOut.Sub(shiftOut).Minutes())
+	if extraMinutes <= threshold {
+		return nil, false
+	}
+
+	var existingCount int64
+	db.Model(&models.OvertimeRequest{}).Where("employee_id = ? AND date = ? AND status IN ?", employee.ID, attendance.AttendanceDate, []string{"Pending", "Accepted"}).Count(&existingCount)
+	if existingCount > 0 {
+		return nil, false
+	}
+
+	return map[string]interface{}{
+		"date":          attendance.AttendanceDate,
+		"in_time":       shiftOut.Format("15:04"),
+		"out_time":      checkOut.Format("15:04"),
+		"total_minutes": extraMinutes,
+	}, true
+}
+
+// GetOvertimeSuggestionsByEmployee menampilkan saran lembur dari absensi 14 hari terakhir yang belum diajukan
+func GetOvertimeSuggestionsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		today := time.Now().In(loc)
+		startDate := today.AddDate(0, 0, -13).Format("2006-01-02")
+
+		var attendances []models.Attendance
+		db.Where("employee_id = ? AND attendance_date BETWEEN ? AND ? AND out_time <> ?", employee.ID, startDate, today.Format("2006-01-02"), "").
+			Order("attendance_date DESC").
+			Find(&attendances)
+
+		suggestions := []map[string]interface{}{}
+		for _, attendance := range attendances {
+			if suggestion, ok := suggestOvertime(db, employee, attendance); ok {
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Overtime suggestions retrieved successfully",
+			"data":    suggestions,
+		})
+	}
+}
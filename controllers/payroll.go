@@ -218,8 +218,11 @@ func UpdatePaidStatusByPayrollID(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 			}
 		}
 
+		// Gaji pokok diprorata jika ada perubahan gaji yang berlaku di tengah bulan
+		proratedSalary := proratedBasicSalary(db, employee, time.Now())
+
 		// Calculate final salary after all deductions and additions
-		finalSalary := employee.BasicSalary - lateDeduction - earlyLeavingDeduction + overtimePay - float64(totalLoanDeduction)
+		finalSalary := proratedSalary - lateDeduction - earlyLeavingDeduction + overtimePay - float64(totalLoanDeduction)
 
 		// Update employee's paid status and create payroll info
 		employee.PaidStatus = true
@@ -246,7 +249,7 @@ func UpdatePaidStatusByPayrollID(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 			if err := helper.SendSalaryTransferNotification(email, fullName, basicSalary, finalSalary, lateDeduction, earlyLeavingDeduction, overtimePay, totalLoanDeduction); err != nil {
 				fmt.Println("Failed to send salary transfer notification email:", err)
 			}
-		}(employee.Email, employee.FirstName+" "+employee.LastName, employee.BasicSalary, finalSalary, lateDeduction, earlyLeavingDeduction, overtimePay, float64(totalLoanDeduction))
+		}(employee.Email, employee.FirstName+" "+employee.LastName, proratedSalary, finalSalary, lateDeduction, earlyLeavingDeduction, overtimePay, float64(totalLoanDeduction))
 
 		// Membuat response sukses
 		successResponse := map[string]interface{}{
@@ -276,6 +279,7 @@ func UpdatePaidStatusByPayrollID(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 				"pay_slip_type":           employee.PaySlipType,
 				"is_active":               employee.IsActive,
 				"paid_status":             employee.PaidStatus,
+				"prorated_basic_salary":   proratedSalary,
 				"final_salary":            finalSalary,
 				"late_deduction":          lateDeduction,
 				"early_leaving_deduction": earlyLeavingDeduction,
@@ -72,9 +72,18 @@ func CreatePolicyByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		policy.CreatedByAdminID = adminUser.ID
 		policy.CreatedByAdminUsername = adminUser.Username
+		policy.LatestVersion = 0
 
 		db.Create(&policy)
 
+		// Isi awal kebijakan menjadi versi 1 yang langsung berlaku
+		publishPolicyVersion(db, &policy, models.PolicyVersion{
+			Content:                  policy.Description,
+			ChangeSummary:            "Initial version",
+			PublishedByAdminID:       adminUser.ID,
+			PublishedByAdminUsername: adminUser.Username,
+		})
+
 		successResponse := helper.Response{
 			Code:    http.StatusCreated,
 			Error:   false,
@@ -363,6 +372,7 @@ func DeletePolicyByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
+		deletePolicyVersions(db, existingPolicy.ID)
 		db.Delete(&existingPolicy)
 
 		successResponse := helper.Response{
@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AcknowledgePolicyRequest struct {
+	VersionID uint `json:"version_id"`
+	Agree     bool `json:"agree"` // Pernyataan "Saya telah membaca dan menyetujui"
+}
+
+// employeePolicyResponse menggabungkan kebijakan, versi aktif dan status acknowledgement karyawan
+func employeePolicyResponse(policy models.Policy, version models.PolicyVersion, acknowledgement *models.PolicyAcknowledgement, includeContent bool) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":              policy.ID,
+		"title":           policy.Title,
+		"description":     policy.Description,
+		"version_id":      version.ID,
+		"version_number":  version.VersionNumber,
+		"effective_date":  version.EffectiveDate,
+		"change_summary":  version.ChangeSummary,
+		"has_document":    version.DocumentKey != "",
+		"acknowledged":    acknowledgement != nil,
+		"acknowledged_at": nil,
+	}
+	if acknowledgement != nil {
+		response["acknowledged_at"] = acknowledgement.AcknowledgedAt
+	}
+	if includeContent {
+		response["content"] = version.Content
+	}
+	return response
+}
+
+// GetPoliciesByEmployee menampilkan versi aktif setiap kebijakan, filter status=pending|acknowledged
+func GetPoliciesByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var policies []models.Policy
+		db.Order("id ASC").Find(&policies)
+
+		var acknowledgements []models.PolicyAcknowledgement
+		db.Where("employee_id = ?", employee.ID).Find(&acknowledgements)
+		acknowledgedVersions := map[uint]*models.PolicyAcknowledgement{}
+		for i := range acknowledgements {
+			acknowledgedVersions[acknowledgements[i].PolicyVersionID] = &acknowledgements[i]
+		}
+
+		status := c.QueryParam("status")
+		pendingCount := 0
+		response := []map[string]interface{}{}
+		for i := range policies {
+			version, ok := currentPolicyVersion(db, &policies[i])
+			if !ok {
+				continue
+			}
+
+			acknowledgement := acknowledgedVersions[version.ID]
+			if acknowledgement == nil {
+				pendingCount++
+			}
+			if (status == "pending" && acknowledgement != nil) || (status == "acknowledged" && acknowledgement == nil) {
+				continue
+			}
+			response = append(response, employeePolicyResponse(policies[i], version, acknowledgement, false))
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":          http.StatusOK,
+			"error":         false,
+			"message":       "Policies retrieved successfully",
+			"data":          response,
+			"pending_count": pendingCount,
+		})
+	}
+}
+
+func GetPolicyByIDByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var policy models.Policy
+		if err := db.First(&policy, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		version, ok := currentPolicyVersion(db, &policy)
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy has no effective version yet"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var acknowledgement *models.PolicyAcknowledgement
+		var existingAck models.PolicyAcknowledgement
+		if err := db.Where("policy_version_id = ? AND employee_id = ?", version.ID, employee.ID).First(&existingAck).Error; err == nil {
+			acknowledgement = &existingAck
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Policy retrieved successfully",
+			"policy":  employeePolicyResponse(policy, version, acknowledgement, true),
+		})
+	}
+}
+
+// AcknowledgePolicyByEmployee mencatat persetujuan karyawan atas versi kebijakan yang sedang berlaku
+func AcknowledgePolicyByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var policy models.Policy
+		if err := db.First(&policy, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request AcknowledgePolicyRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if !request.Agree {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "You must confirm that you have read and agree to the policy"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		version, ok := currentPolicyVersion(db, &policy)
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy has no effective version yet"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Karyawan harus menyetujui versi yang sama dengan yang dibacanya
+		if request.VersionID != 0 && request.VersionID != version.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "A newer version of this policy has been published, please review it again"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		var acknowledgement models.PolicyAcknowledgement
+		if err := db.Where("policy_version_id = ? AND employee_id = ?", version.ID, employee.ID).First(&acknowledgement).Error; err == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Policy version already acknowledged"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		currentTime := time.Now()
+		acknowledgement = models.PolicyAcknowledgement{
+			PolicyID:        policy.ID,
+			PolicyVersionID: version.ID,
+			VersionNumber:   version.VersionNumber,
+			EmployeeID:      employee.ID,
+			IPAddress:       c.RealIP(),
+			AcknowledgedAt:  &currentTime,
+		}
+		if err := db.Create(&acknowledgement).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to acknowledge policy"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Policy acknowledged successfully",
+			"policy":  employeePolicyResponse(policy, version, &acknowledgement, false),
+		})
+	}
+}
+
+func DownloadPolicyDocumentByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var policy models.Policy
+		if err := db.First(&policy, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		version, ok := currentPolicyVersion(db, &policy)
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy has no effective version yet"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamPolicyDocument(c, version)
+	}
+}
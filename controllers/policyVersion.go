@@ -0,0 +1,616 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxPolicyDocumentSize = 10 * 1024 * 1024
+
+// PolicyVersionRequest dapat dikirim sebagai JSON atau multipart form (jika menyertakan file PDF "document")
+type PolicyVersionRequest struct {
+	Content       string `json:"content" form:"content"`
+	ChangeSummary string `json:"change_summary" form:"change_summary"`
+	EffectiveDate string `json:"effective_date" form:"effective_date"`
+}
+
+// publishPolicyVersion menambahkan versi baru, acknowledgement versi lama tidak berlaku lagi untuk versi ini
+func publishPolicyVersion(db *gorm.DB, policy *models.Policy, version models.PolicyVersion) (models.PolicyVersion, error) {
+	currentTime := time.Now()
+	version.ID = 0
+	version.PolicyID = policy.ID
+	version.VersionNumber = policy.LatestVersion + 1
+	version.CreatedAt = &currentTime
+	if version.EffectiveDate == "" {
+		version.EffectiveDate = currentTime.Format("2006-01-02")
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+		return tx.Model(policy).Update("latest_version", version.VersionNumber).Error
+	})
+	if err == nil {
+		policy.LatestVersion = version.VersionNumber
+	}
+	return version, err
+}
+
+// currentPolicyVersion mengembalikan versi terbaru yang sudah efektif, false jika belum ada versi yang berlaku
+func currentPolicyVersion(db *gorm.DB, policy *models.Policy) (models.PolicyVersion, bool) {
+	var version models.PolicyVersion
+	err := db.Where("policy_id = ? AND effective_date <= ?", policy.ID, time.Now().Format("2006-01-02")).
+		Order("version_number DESC").First(&version).Error
+	return version, err == nil
+}
+
+// storePolicyDocument mengunggah lampiran PDF versi kebijakan jika ada
+func storePolicyDocument(c echo.Context, policyID uint, versionNumber int) (string, string, int, string) {
+	file, err := c.FormFile("document")
+	if err != nil {
+		return "", "", http.StatusOK, ""
+	}
+
+	if helper.IsFileSizeExceeds(file, maxPolicyDocumentSize) {
+		return "", "", http.StatusBadRequest, "Policy document must not exceed 10MB"
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", "", http.StatusInternalServerError, "Failed to open policy document"
+	}
+	defer src.Close()
+
+	fileData, err := io.ReadAll(src)
+	if err != nil {
+		return "", "", http.StatusInternalServerError, "Failed to read policy document"
+	}
+
+	if helper.DetectContentType(fileData, file.Filename) != "application/pdf" {
+		return "", "", http.StatusBadRequest, "Policy document must be a PDF file"
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		return "", "", http.StatusInternalServerError, "Failed to initialize file storage"
+	}
+
+	documentKey := fmt.Sprintf("policies/%d/v%d.pdf", policyID, versionNumber)
+	if err := fileStorage.Upload(documentKey, fileData, "application/pdf"); err != nil {
+		return "", "", http.StatusInternalServerError, "Failed to upload policy document"
+	}
+
+	return file.Filename, documentKey, http.StatusOK, ""
+}
+
+func streamPolicyDocument(c echo.Context, version models.PolicyVersion) error {
+	if version.DocumentKey == "" {
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy version has no document"}
+		return c.JSON(http.StatusNotFound, errorResponse)
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	reader, err := fileStorage.Open(version.DocumentKey)
+	if err != nil {
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Policy document not found"}
+		return c.JSON(http.StatusNotFound, errorResponse)
+	}
+	defer reader.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", version.DocumentName))
+	return c.Stream(http.StatusOK, "application/pdf", reader)
+}
+
+// deletePolicyVersions dipanggil saat kebijakan dihapus
+func deletePolicyVersions(db *gorm.DB, policyID uint) {
+	var versions []models.PolicyVersion
+	db.Where("policy_id = ?", policyID).Find(&versions)
+
+	if fileStorage, err := helper.NewFileStorage(); err == nil {
+		for _, version := range versions {
+			if version.DocumentKey != "" {
+				if err := fileStorage.Delete(version.DocumentKey); err != nil {
+					fmt.Println("Failed to delete policy document:", err)
+				}
+			}
+		}
+	}
+
+	db.Where("policy_id = ?", policyID).Delete(&models.PolicyAcknowledgement{})
+	db.Where("policy_id = ?", policyID).Delete(&models.PolicyVersion{})
+}
+
+// PublishPolicyVersionByAdmin menerbitkan versi baru kebijakan, karyawan wajib acknowledge ulang setelah versi efektif
+func PublishPolicyVersionByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var policy models.Policy
+		result = db.First(&policy, c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request PolicyVersionRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(request.Content) < 5 || len(request.Content) > 20000 {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Policy content must be between 5 and 20000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.EffectiveDate == "" {
+			request.EffectiveDate = time.Now().Format("2006-01-02")
+		}
+		if _, err := time.Parse("2006-01-02", request.EffectiveDate); err != nil {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Invalid effective date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var latestVersion models.PolicyVersion
+		if err := db.Where("policy_id = ?", policy.ID).Order("version_number DESC").First(&latestVersion).Error; err == nil {
+			if request.EffectiveDate < latestVersion.EffectiveDate {
+				errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Effective date cannot be earlier than the latest version (" + latestVersion.EffectiveDate + ")"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		documentName, documentKey, status, message := storePolicyDocument(c, policy.ID, policy.LatestVersion+1)
+		if message != "" {
+			errorResponse := helper.Response{Code: status, Error: true, Message: message}
+			return c.JSON(status, errorResponse)
+		}
+
+		version, err := publishPolicyVersion(db, &policy, models.PolicyVersion{
+			Content:                  request.Content,
+			ChangeSummary:            request.ChangeSummary,
+			EffectiveDate:            request.EffectiveDate,
+			DocumentName:             documentName,
+			DocumentKey:              documentKey,
+			PublishedByAdminID:       adminUser.ID,
+			PublishedByAdminUsername: adminUser.Username,
+		})
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusInternalServerError, Error: true, Message: "Failed to publish policy version"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Policy version published successfully",
+			"policy":  policy,
+			"version": version,
+		})
+	}
+}
+
+func GetPolicyVersionsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var policy models.Policy
+		result = db.First(&policy, c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		currentVersion, _ := currentPolicyVersion(db, &policy)
+
+		var versions []models.PolicyVersion
+		db.Where("policy_id = ?", policy.ID).Order("version_number DESC").Find(&versions)
+
+		type ackCount struct {
+			PolicyVersionID uint
+			Count           int
+		}
+		var counts []ackCount
+		db.Model(&models.PolicyAcknowledgement{}).Select("policy_version_id, count(*) as count").Where("policy_id = ?", policy.ID).Group("policy_version_id").Scan(&counts)
+
+		acknowledgements := map[uint]int{}
+		for _, count := range counts {
+			acknowledgements[count.PolicyVersionID] = count.Count
+		}
+
+		var response []map[string]interface{}
+		for _, version := range versions {
+			response = append(response, map[string]interface{}{
+				"version":          version,
+				"is_current":       version.ID == currentVersion.ID,
+				"has_document":     version.DocumentKey != "",
+				"acknowledgements": acknowledgements[version.ID],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Policy versions retrieved successfully",
+			"policy":   policy,
+			"versions": response,
+		})
+	}
+}
+
+func DownloadPolicyVersionDocumentByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var version models.PolicyVersion
+		result = db.Where("id = ? AND policy_id = ?", c.Param("version_id"), c.Param("id")).First(&version)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Policy version not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return streamPolicyDocument(c, version)
+	}
+}
+
+// GetPolicyComplianceReportByAdmin menampilkan acknowledgement yang belum dipenuhi untuk versi aktif setiap kebijakan, per departemen
+func GetPolicyComplianceReportByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var policies []models.Policy
+		db.Order("id ASC").Find(&policies)
+
+		var versionIDs []uint
+		currentVersions := map[uint]models.PolicyVersion{}
+		for i := range policies {
+			if version, ok := currentPolicyVersion(db, &policies[i]); ok {
+				currentVersions[policies[i].ID] = version
+				versionIDs = append(versionIDs, version.ID)
+			}
+		}
+
+		departmentFilter := c.QueryParam("department_id")
+
+		// Jumlah karyawan aktif per departemen
+		type departmentCount struct {
+			DepartmentID uint
+			Count        int
+		}
+		var employeeCounts []departmentCount
+		employeeQuery := db.Model(&models.Employee{}).Select("department_id, count(*) as count").Where("is_client = ? AND is_exit = ?", false, false)
+		if departmentFilter != "" {
+			employeeQuery = employeeQuery.Where("department_id = ?", departmentFilter)
+		}
+		employeeQuery.Group("department_id").Scan(&employeeCounts)
+
+		// Jumlah acknowledgement versi aktif per departemen dan versi
+		type ackCount struct {
+			DepartmentID    uint
+			PolicyVersionID uint
+			Count           int
+		}
+		var ackCounts []ackCount
+		if len(versionIDs) > 0 {
+			ackQuery := db.Model(&models.PolicyAcknowledgement{}).
+				Select("employees.department_id, policy_acknowledgements.policy_version_id, count(*) as count").
+				Joins("JOIN employees ON employees.id = policy_acknowledgements.employee_id").
+				Where("policy_acknowledgements.policy_version_id IN ? AND employees.is_client = ? AND employees.is_exit = ?", versionIDs, false, false)
+			if departmentFilter != "" {
+				ackQuery = ackQuery.Where("employees.department_id = ?", departmentFilter)
+			}
+			ackQuery.Group("employees.department_id, policy_acknowledgements.policy_version_id").Scan(&ackCounts)
+		}
+
+		acknowledged := map[uint]map[uint]int{}
+		for _, count := range ackCounts {
+			if acknowledged[count.DepartmentID] == nil {
+				acknowledged[count.DepartmentID] = map[uint]int{}
+			}
+			acknowledged[count.DepartmentID][count.PolicyVersionID] = count.Count
+		}
+
+		var departments []models.Department
+		db.Find(&departments)
+		departmentNames := map[uint]string{}
+		for _, department := range departments {
+			departmentNames[department.ID] = department.DepartmentName
+		}
+
+		var report []map[string]interface{}
+		totalRequired, totalAcknowledged := 0, 0
+		for _, employeeCount := range employeeCounts {
+			departmentName, ok := departmentNames[employeeCount.DepartmentID]
+			if !ok {
+				departmentName = "No Department"
+			}
+
+			var policyRows []map[string]interface{}
+			departmentRequired, departmentAcknowledged := 0, 0
+			for _, policy := range policies {
+				version, ok := currentVersions[policy.ID]
+				if !ok {
+					continue
+				}
+				ackCount := acknowledged[employeeCount.DepartmentID][version.ID]
+				policyRows = append(policyRows, map[string]interface{}{
+					"policy_id":      policy.ID,
+					"title":          policy.Title,
+					"version_number": version.VersionNumber,
+					"acknowledged":   ackCount,
+					"outstanding":    employeeCount.Count - ackCount,
+				})
+				departmentRequired += employeeCount.Count
+				departmentAcknowledged += ackCount
+			}
+
+			report = append(report, map[string]interface{}{
+				"department_id":   employeeCount.DepartmentID,
+				"department_name": departmentName,
+				"total_employees": employeeCount.Count,
+				"required":        departmentRequired,
+				"acknowledged":    departmentAcknowledged,
+				"outstanding":     departmentRequired - departmentAcknowledged,
+				"compliance_rate": policyComplianceRate(departmentAcknowledged, departmentRequired),
+				"policies":        policyRows,
+			})
+			totalRequired += departmentRequired
+			totalAcknowledged += departmentAcknowledged
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Policy compliance report retrieved successfully",
+			"summary": map[string]interface{}{
+				"required":        totalRequired,
+				"acknowledged":    totalAcknowledged,
+				"outstanding":     totalRequired - totalAcknowledged,
+				"compliance_rate": policyComplianceRate(totalAcknowledged, totalRequired),
+			},
+			"departments": report,
+		})
+	}
+}
+
+// GetOutstandingPolicyAcknowledgementsByAdmin menampilkan karyawan yang belum acknowledge versi aktif kebijakan
+func GetOutstandingPolicyAcknowledgementsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.Response{Code: http.StatusUnauthorized, Error: true, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.Response{Code: http.StatusForbidden, Error: true, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var policy models.Policy
+		result = db.First(&policy, c.Param("id"))
+		if result.Error != nil {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Policy not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		version, ok := currentPolicyVersion(db, &policy)
+		if !ok {
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Policy has no effective version yet"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.Employee{}).
+			Where("is_client = ? AND is_exit = ?", false, false).
+			Where("id NOT IN (?)", db.Model(&models.PolicyAcknowledgement{}).Select("employee_id").Where("policy_version_id = ?", version.ID))
+		if departmentID := c.QueryParam("department_id"); departmentID != "" {
+			query = query.Where("department_id = ?", departmentID)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var employees []models.Employee
+		query.Order("first_name ASC").Offset(offset).Limit(perPage).Find(&employees)
+
+		outstanding := []map[string]interface{}{}
+		for _, employee := range employees {
+			outstanding = append(outstanding, map[string]interface{}{
+				"employee_id": employee.ID,
+				"username":    employee.Username,
+				"full_name":   employee.FirstName + " " + employee.LastName,
+				"email":       employee.Email,
+				"department":  employee.Department,
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"message":        "Outstanding policy acknowledgements retrieved successfully",
+			"policy_id":      policy.ID,
+			"version_number": version.VersionNumber,
+			"data":           outstanding,
+			"pagination": map[string]interface{}{
+				"total_count": totalCount,
+				"page":        page,
+				"per_page":    perPage,
+			},
+		})
+	}
+}
+
+func policyComplianceRate(acknowledged, required int) float64 {
+	if required == 0 {
+		return 100
+	}
+	return math.Round(float64(acknowledged)/float64(required)*10000) / 100
+}
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Ukuran maksimal foto profil sebelum diperkecil (5MB)
+const maxProfilePhotoSize = 5 * 1024 * 1024
+
+func profilePhotoObjectName(employeeID uint, size string) string {
+	return fmt.Sprintf("profile_photos/%d/%s.jpg", employeeID, size)
+}
+
+// profilePhotoURL mengarah ke endpoint /avatars, parameter v dipakai untuk cache busting setelah foto diganti
+func profilePhotoURL(employeeID uint, size string, version int64) string {
+	return fmt.Sprintf("%s/avatars/%d/%s?v=%d", strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/"), employeeID, size, version)
+}
+
+// employeeAvatarURL mengambil URL avatar karyawan, dipakai pada respons helpdesk dan catatan task
+func employeeAvatarURL(db *gorm.DB, employeeID uint) string {
+	if employeeID == 0 {
+		return ""
+	}
+	var employee models.Employee
+	if err := db.Select("id", "avatar_url").First(&employee, employeeID).Error; err != nil {
+		return ""
+	}
+	return employee.AvatarURL
+}
+
+// storeProfilePhoto memvalidasi foto, membuat ukuran standar dan thumbnail, lalu menyimpannya melalui storage
+func storeProfilePhoto(db *gorm.DB, employee *models.Employee, file *multipart.FileHeader) (int, string) {
+	if !helper.IsImageFile(file) {
+		return http.StatusBadRequest, "Profile photo must be an image file"
+	}
+	if helper.IsFileSizeExceeds(file, maxProfilePhotoSize) {
+		return http.StatusBadRequest, "Profile photo must not exceed 5MB"
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return http.StatusInternalServerError, "Failed to open profile photo"
+	}
+	defer src.Close()
+
+	photoData, err := io.ReadAll(src)
+	if err != nil {
+		return http.StatusInternalServerError, "Failed to read profile photo"
+	}
+
+	// Header Content-Type dari client tidak cukup, pastikan isi file memang gambar
+	if !strings.HasPrefix(helper.DetectContentType(photoData, file.Filename), "image/") {
+		return http.StatusBadRequest, "Profile photo must be an image file"
+	}
+
+	standard, thumbnail, err := helper.ResizeProfilePhoto(photoData)
+	if errors.Is(err, helper.ErrImageTooLarge) {
+		return http.StatusBadRequest, "Image dimensions are too large. Maximum is 25 megapixels"
+	}
+	if err != nil {
+		return http.StatusBadRequest, "Unsupported image format. Use JPEG, PNG or GIF"
+	}
+
+	fileStorage, err := helper.NewFileStorage()
+	if err != nil {
+		return http.StatusInternalServerError, "Failed to initialize file storage"
+	}
+
+	if err := fileStorage.Upload(profilePhotoObjectName(employee.ID, "standard"), standard, "image/jpeg"); err != nil {
+		return http.StatusInternalServerError, "Failed to upload profile photo"
+	}
+	if err := fileStorage.Upload(profilePhotoObjectName(employee.ID, "thumbnail"), thumbnail, "image/jpeg"); err != nil {
+		return http.StatusInternalServerError, "Failed to upload profile photo"
+	}
+
+	version := time.Now().Unix()
+	employee.ProfilePhotoURL = profilePhotoURL(employee.ID, "standard", version)
+	employee.AvatarURL = profilePhotoURL(employee.ID, "thumbnail", version)
+	if err := db.Model(employee).Updates(map[string]interface{}{"profile_photo_url": employee.ProfilePhotoURL, "avatar_url": employee.AvatarURL}).Error; err != nil {
+		return http.StatusInternalServerError, "Failed to update profile photo"
+	}
+	db.Model(&models.Note{}).Where("employee_id = ?", employee.ID).Update("avatar_url", employee.AvatarURL)
+
+	return http.StatusOK, ""
+}
+
+func UploadProfilePhotoByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"})
+		}
+
+		file, err := c.FormFile("photo")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Photo file is required"})
+		}
+
+		if status, message := storeProfilePhoto(db, &employee, file); message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":              http.StatusOK,
+			"error":             false,
+			"message":           "Profile photo updated successfully",
+			"avatar_url":        employee.AvatarURL,
+			"profile_photo_url": employee.ProfilePhotoURL,
+		})
+	}
+}
+
+func DeleteProfilePhotoByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"})
+		}
+
+		if employee.AvatarURL == "" {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Profile photo not found"})
+		}
+
+		if fileStorage, err := helper.NewFileStorage(); err == nil {
+			for _, size := range []string{"standard", "thumbnail"} {
+				if err := fileStorage.Delete(profilePhotoObjectName(employee.ID, size)); err != nil {
+					fmt.Println("Failed to delete profile photo:", err)
+				}
+			}
+		}
+
+		db.Model(&employee).Updates(map[string]interface{}{"profile_photo_url": "", "avatar_url": ""})
+		db.Model(&models.Note{}).Where("employee_id = ?", employee.ID).Update("avatar_url", "")
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Profile photo deleted successfully",
+		})
+	}
+}
+
+func UploadEmployeePhotoByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"})
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"})
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"})
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"})
+		}
+
+		if !adminUser.IsAdminHR {
+			return c.JSON(http.StatusForbidden, helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"})
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, "id = ?", c.Param("id"))
+		if result.Error != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"})
+		}
+
+		file, err := c.FormFile("photo")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Photo file is required"})
+		}
+
+		if status, message := storeProfilePhoto(db, &employee, file); message != "" {
+			return c.JSON(status, helper.ErrorResponse{Code: status, Message: message})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":              http.StatusOK,
+			"error":             false,
+			"message":           "Employee photo updated successfully",
+			"avatar_url":        employee.AvatarURL,
+			"profile_photo_url": employee.ProfilePhotoURL,
+		})
+	}
+}
+
+// GetEmployeeAvatar menampilkan foto profil (size: thumbnail atau standard) tanpa token agar dapat dipakai langsung pada tag <img>
+func GetEmployeeAvatar(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		size := c.Param("size")
+		if size != "thumbnail" && size != "standard" {
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Size must be thumbnail or standard"})
+		}
+
+		var employee models.Employee
+		if err := db.Select("id", "avatar_url").First(&employee, "id = ?", c.Param("id")).Error; err != nil || employee.AvatarURL == "" {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Profile photo not found"})
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"})
+		}
+
+		reader, err := fileStorage.Open(profilePhotoObjectName(employee.ID, size))
+		if err != nil {
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Profile photo not found"})
+		}
+		defer reader.Close()
+
+		c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+		return c.Stream(http.StatusOK, "image/jpeg", reader)
+	}
+}
@@ -12,6 +12,23 @@ import (
 	"time"
 )
 
+// validateShiftAttendanceRules memastikan aturan absensi shift bernilai wajar
+func validateShiftAttendanceRules(shift models.Shift) string {
+	if shift.LateGraceMinutes < 0 || shift.BreakMinutes < 0 || shift.HalfDayThresholdMinutes < 0 {
+		return "Attendance rule minutes cannot be negative"
+	}
+	if shift.RoundingMinutes != 0 && shift.RoundingMinutes != 5 && shift.RoundingMinutes != 15 {
+		return "Rounding minutes must be 0, 5 or 15"
+	}
+	if shift.LateGraceMinutes > 120 {
+		return "Late grace minutes cannot exceed 120"
+	}
+	if shift.BreakMinutes > 240 {
+		return "Break minutes cannot exceed 240"
+	}
+	return ""
+}
+
 func CreateShiftByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		tokenString := c.Request().Header.Get("Authorization")
@@ -63,6 +80,11 @@ func CreateShiftByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		if message := validateShiftAttendanceRules(shift); message != "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: message}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
 		var existingShift models.Shift
 		result = db.Where("shift_name = ?", shift.ShiftName).First(&existingShift)
 		if result.Error == nil {
@@ -401,6 +423,12 @@ func DeleteShiftByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		db.Model(&models.ShiftRoster{}).Where("shift_id = ?", shiftID).Count(&count)
+		if count > 0 {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Cannot delete shift because it is used in one or more shift rosters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
 		db.Delete(&shift)
 
 		successResponse := helper.Response{
@@ -411,3 +439,94 @@ func DeleteShiftByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		return c.JSON(http.StatusOK, successResponse)
 	}
 }
+
+type ShiftAttendanceRulesRequest struct {
+	LateGraceMinutes        *int `json:"late_grace_minutes"`
+	RoundingMinutes         *int `json:"rounding_minutes"`
+	BreakMinutes            *int `json:"break_minutes"`
+	HalfDayThresholdMinutes *int `json:"half_day_threshold_minutes"`
+}
+
+// UpdateShiftAttendanceRulesByAdmin mengubah aturan grace period, pembulatan, istirahat dan half day sebuah shift
+func UpdateShiftAttendanceRulesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		shiftID := c.Param("id")
+		if shiftID == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift ID is missing"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var shift models.Shift
+		result = db.First(&shift, "id = ?", shiftID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request ShiftAttendanceRulesRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.LateGraceMinutes != nil {
+			shift.LateGraceMinutes = *request.LateGraceMinutes
+		}
+		if request.RoundingMinutes != nil {
+			shift.RoundingMinutes = *request.RoundingMinutes
+		}
+		if request.BreakMinutes != nil {
+			shift.BreakMinutes = *request.BreakMinutes
+		}
+		if request.HalfDayThresholdMinutes != nil {
+			shift.HalfDayThresholdMinutes = *request.HalfDayThresholdMinutes
+		}
+
+		if message := validateShiftAttendanceRules(shift); message != "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: message}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		db.Save(&shift)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift attendance rules updated successfully",
+			"data":    shift,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
@@ -0,0 +1,814 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// getEffectiveShiftID returns the shift that applies to the employee on the given date (yyyy-mm-dd).
+// A roster entry for that date overrides the employee's default shift.
+func getEffectiveShiftID(db *gorm.DB, employee models.Employee, date string) uint {
+	var roster models.ShiftRoster
+	result := db.Where("employee_id = ? AND roster_date = ?", employee.ID, date).First(&roster)
+	if result.Error == nil && roster.ShiftID != 0 {
+		return roster.ShiftID
+	}
+	return employee.ShiftID
+}
+
+// upsertShiftRoster assigns a shift to an employee for a single date, replacing any existing assignment.
+func upsertShiftRoster(tx *gorm.DB, employee models.Employee, shift models.Shift, date string, remarks string) (models.ShiftRoster, error) {
+	var roster models.ShiftRoster
+	result := tx.Where("employee_id = ? AND roster_date = ?", employee.ID, date).First(&roster)
+
+	currentTime := time.Now()
+	roster.EmployeeID = employee.ID
+	roster.FullNameEmployee = employee.FirstName + " " + employee.LastName
+	roster.DepartmentID = employee.DepartmentID
+	roster.ShiftID = shift.ID
+	roster.ShiftName = shift.ShiftName
+	roster.RosterDate = date
+	if remarks != "" {
+		roster.Remarks = remarks
+	}
+
+	if result.Error != nil {
+		roster.CreatedAt = &currentTime
+		if err := tx.Create(&roster).Error; err != nil {
+			return roster, err
+		}
+		return roster, nil
+	}
+
+	if err := tx.Save(&roster).Error; err != nil {
+		return roster, err
+	}
+	return roster, nil
+}
+
+type BulkShiftRosterRequest struct {
+	DepartmentID uint            `json:"department_id"`
+	WeekStart    string          `json:"week_start"` // Format: yyyy-mm-dd, must be a Monday
+	ShiftID      uint            `json:"shift_id"`
+	DailyShifts  map[string]uint `json:"daily_shifts"` // Keyed by weekday name, e.g. "Monday"
+	EmployeeIDs  []uint          `json:"employee_ids"`
+	Remarks      string          `json:"remarks"`
+	Overwrite    bool            `json:"overwrite"`
+}
+
+func CreateShiftRosterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var roster models.ShiftRoster
+		if err := c.Bind(&roster); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if roster.EmployeeID == 0 || roster.ShiftID == 0 || roster.RosterDate == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Employee ID, shift ID and roster date are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if _, err := time.Parse("2006-01-02", roster.RosterDate); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid roster date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var employee models.Employee
+		result = db.First(&employee, roster.EmployeeID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var shift models.Shift
+		result = db.First(&shift, roster.ShiftID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		savedRoster, err := upsertShiftRoster(db, employee, shift, roster.RosterDate, roster.Remarks)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save shift roster"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Shift roster saved successfully",
+			"data":    savedRoster,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+func BuildWeeklyShiftRosterByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var request BulkShiftRosterRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.DepartmentID == 0 || request.WeekStart == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Department ID and week start are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if request.ShiftID == 0 && len(request.DailyShifts) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Either shift_id or daily_shifts must be provided"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		weekStart, err := time.Parse("2006-01-02", request.WeekStart)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid week start format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if weekStart.Weekday() != time.Monday {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Week start must be a Monday"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var department models.Department
+		result = db.First(&department, request.DepartmentID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Department not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Resolve the shift for every day of the week up front so an invalid shift fails the whole request
+		shiftByDay := make(map[string]models.Shift)
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i).Weekday().String()
+			shiftID := request.ShiftID
+			if dailyShiftID, ok := request.DailyShifts[day]; ok {
+				shiftID = dailyShiftID
+			}
+			if shiftID == 0 {
+				continue
+			}
+
+			var shift models.Shift
+			if err := db.First(&shift, shiftID).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: fmt.Sprintf("Shift not found for %s", day)}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			shiftByDay[day] = shift
+		}
+
+		query := db.Where("department_id = ? AND is_client = ? AND is_exit = ?", request.DepartmentID, false, false)
+		if len(request.EmployeeIDs) > 0 {
+			query = query.Where("id IN (?)", request.EmployeeIDs)
+		}
+
+		var employees []models.Employee
+		if err := query.Find(&employees).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employees"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if len(employees) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "No active employees found in the department"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		createdCount := 0
+		skippedCount := 0
+		tx := db.Begin()
+		for _, employee := range employees {
+			for i := 0; i < 7; i++ {
+				date := weekStart.AddDate(0, 0, i)
+				shift, ok := shiftByDay[date.Weekday().String()]
+				if !ok {
+					continue
+				}
+
+				dateStr := date.Format("2006-01-02")
+				if !request.Overwrite {
+					var existing models.ShiftRoster
+					if err := tx.Where("employee_id = ? AND roster_date = ?", employee.ID, dateStr).First(&existing).Error; err == nil {
+						skippedCount++
+						continue
+					}
+				}
+
+				if _, err := upsertShiftRoster(tx, employee, shift, dateStr, request.Remarks); err != nil {
+					tx.Rollback()
+					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save shift roster"}
+					return c.JSON(http.StatusInternalServerError, errorResponse)
+				}
+				createdCount++
+			}
+		}
+		tx.Commit()
+
+		successResponse := map[string]interface{}{
+			"code":            http.StatusCreated,
+			"error":           false,
+			"message":         "Weekly shift roster built successfully",
+			"department_id":   department.ID,
+			"department_name": department.DepartmentName,
+			"week_start":      weekStart.Format("2006-01-02"),
+			"week_end":        weekStart.AddDate(0, 0, 6).Format("2006-01-02"),
+			"employees":       len(employees),
+			"saved":           createdCount,
+			"skipped":         skippedCount,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+func GetAllShiftRostersByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		startDate := c.QueryParam("start_date")
+		endDate := c.QueryParam("end_date")
+		employeeID := c.QueryParam("employee_id")
+		departmentID := c.QueryParam("department_id")
+		searching := c.QueryParam("searching")
+
+		query := db.Model(&models.ShiftRoster{})
+
+		if startDate != "" {
+			query = query.Where("roster_date >= ?", startDate)
+		}
+
+		if endDate != "" {
+			query = query.Where("roster_date <= ?", endDate)
+		}
+
+		if employeeID != "" {
+			query = query.Where("employee_id = ?", employeeID)
+		}
+
+		if departmentID != "" {
+			query = query.Where("department_id = ?", departmentID)
+		}
+
+		if searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("full_name_employee ILIKE ? OR shift_name ILIKE ?", searchPattern, searchPattern)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var rosters []models.ShiftRoster
+		query.Order("roster_date ASC, id ASC").Offset(offset).Limit(perPage).Find(&rosters)
+
+		successResponse := map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Shift rosters retrieved successfully",
+			"data":       rosters,
+			"pagination": map[string]interface{}{"total_count": totalCount, "page": page, "per_page": perPage},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func GetShiftRosterByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		rosterID := c.Param("id")
+		if rosterID == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift roster ID is missing"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var roster models.ShiftRoster
+		result = db.First(&roster, "id = ?", rosterID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift roster not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift roster retrieved successfully",
+			"data":    roster,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func UpdateShiftRosterByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		rosterID := c.Param("id")
+		if rosterID == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift roster ID is missing"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var roster models.ShiftRoster
+		result = db.First(&roster, "id = ?", rosterID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift roster not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var updatedRoster models.ShiftRoster
+		if err := c.Bind(&updatedRoster); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if updatedRoster.ShiftID != 0 {
+			var shift models.Shift
+			result = db.First(&shift, updatedRoster.ShiftID)
+			if result.Error != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift not found"}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+			roster.ShiftID = shift.ID
+			roster.ShiftName = shift.ShiftName
+		}
+
+		if updatedRoster.RosterDate != "" {
+			if _, err := time.Parse("2006-01-02", updatedRoster.RosterDate); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid roster date format. Required format: yyyy-mm-dd"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var existing models.ShiftRoster
+			result = db.Where("employee_id = ? AND roster_date = ? AND id <> ?", roster.EmployeeID, updatedRoster.RosterDate, roster.ID).First(&existing)
+			if result.Error == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Employee already has a roster entry for this date"}
+				return c.JSON(http.StatusConflict, errorResponse)
+			}
+			roster.RosterDate = updatedRoster.RosterDate
+		}
+
+		if updatedRoster.Remarks != "" {
+			roster.Remarks = updatedRoster.Remarks
+		}
+
+		if err := db.Save(&roster).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update shift roster"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift roster updated successfully",
+			"data":    roster,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func DeleteShiftRosterByIDByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		rosterID := c.Param("id")
+		if rosterID == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift roster ID is missing"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var roster models.ShiftRoster
+		result = db.First(&roster, "id = ?", rosterID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift roster not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		db.Delete(&roster)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift roster deleted successfully",
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func GetAllShiftSwapRequestsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		status := c.QueryParam("status")
+		searching := c.QueryParam("searching")
+
+		query := db.Model(&models.ShiftSwapRequest{})
+
+		if status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		if searching != "" {
+			searchPattern := "%" + searching + "%"
+			query = query.Where("requester_full_name ILIKE ? OR target_full_name ILIKE ? OR swap_date ILIKE ?", searchPattern, searchPattern, searchPattern)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var swapRequests []models.ShiftSwapRequest
+		query.Order("id DESC").Offset(offset).Limit(perPage).Find(&swapRequests)
+
+		successResponse := map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Shift swap requests retrieved successfully",
+			"data":       swapRequests,
+			"pagination": map[string]interface{}{"total_count": totalCount, "page": page, "per_page": perPage},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func UpdateShiftSwapRequestByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var adminUser models.Admin
+		result := db.Where("username = ?", username).First(&adminUser)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin user not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if !adminUser.IsAdminHR {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		swapRequestID := c.Param("id")
+		if swapRequestID == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift swap request ID is missing"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var swapRequest models.ShiftSwapRequest
+		result = db.First(&swapRequest, "id = ?", swapRequestID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift swap request not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var updatedSwapRequest models.ShiftSwapRequest
+		if err := c.Bind(&updatedSwapRequest); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if updatedSwapRequest.Status != "Approved" && updatedSwapRequest.Status != "Rejected" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Status must be either Approved or Rejected"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if swapRequest.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Shift swap request has already been reviewed"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var requester models.Employee
+		result = db.First(&requester, swapRequest.RequesterID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Requesting employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var target models.Employee
+		result = db.First(&target, swapRequest.TargetEmployeeID)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Target employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		tx := db.Begin()
+		if updatedSwapRequest.Status == "Approved" {
+			// Re-resolve both shifts at approval time since the roster may have changed since the request
+			var requesterShift, targetShift models.Shift
+			if err := tx.First(&requesterShift, getEffectiveShiftID(tx, requester, swapRequest.SwapDate)).Error; err != nil {
+				tx.Rollback()
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch requester shift"}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			if err := tx.First(&targetShift, getEffectiveShiftID(tx, target, swapRequest.SwapDate)).Error; err != nil {
+				tx.Rollback()
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch target shift"}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+
+			remarks := fmt.Sprintf("Shift swap #%d", swapRequest.ID)
+			if _, err := upsertShiftRoster(tx, requester, targetShift, swapRequest.SwapDate, remarks); err != nil {
+				tx.Rollback()
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update requester roster"}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			if _, err := upsertShiftRoster(tx, target, requesterShift, swapRequest.SwapDate, remarks); err != nil {
+				tx.Rollback()
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update target roster"}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+
+			swapRequest.RequesterShiftID = requesterShift.ID
+			swapRequest.RequesterShiftName = requesterShift.ShiftName
+			swapRequest.TargetShiftID = targetShift.ID
+			swapRequest.TargetShiftName = targetShift.ShiftName
+		}
+
+		swapRequest.Status = updatedSwapRequest.Status
+		swapRequest.ReviewedByAdminID = adminUser.ID
+		swapRequest.ReviewedByAdminName = adminUser.Username
+		if err := tx.Save(&swapRequest).Error; err != nil {
+			tx.Rollback()
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update shift swap request"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		tx.Commit()
+
+		for _, emp := range []models.Employee{requester, target} {
+			err = helper.SendShiftSwapStatusNotification(emp.Email, emp.FirstName+" "+emp.LastName, swapRequest.RequesterFullName, swapRequest.TargetFullName, swapRequest.SwapDate, swapRequest.Status)
+			if err != nil {
+				fmt.Println("Failed to send shift swap status notification:", err)
+			}
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift swap request updated successfully",
+			"data":    swapRequest,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
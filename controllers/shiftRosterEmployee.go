@@ -0,0 +1,383 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"hrsale/helper"
+	"hrsale/middleware"
+	"hrsale/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func GetShiftRosterByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		// Default to the current week (Monday - Sunday)
+		now := time.Now().In(loc)
+		offsetToMonday := (int(now.Weekday()) + 6) % 7
+		startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -offsetToMonday)
+		endDate := startDate.AddDate(0, 0, 6)
+
+		if startDateStr := c.QueryParam("start_date"); startDateStr != "" {
+			startDate, err = time.Parse("2006-01-02", startDateStr)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid start date format. Required format: yyyy-mm-dd"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			endDate = startDate.AddDate(0, 0, 6)
+		}
+
+		if endDateStr := c.QueryParam("end_date"); endDateStr != "" {
+			endDate, err = time.Parse("2006-01-02", endDateStr)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid end date format. Required format: yyyy-mm-dd"}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		if endDate.Before(startDate) || endDate.Sub(startDate).Hours()/24 > 62 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Date range must be between 1 and 62 days"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var rosters []models.ShiftRoster
+		db.Where("employee_id = ? AND roster_date BETWEEN ? AND ?", employee.ID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).Find(&rosters)
+
+		rosterMap := make(map[string]models.ShiftRoster)
+		for _, roster := range rosters {
+			rosterMap[roster.RosterDate] = roster
+		}
+
+		shiftCache := make(map[uint]models.Shift)
+		var schedule []map[string]interface{}
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			dateStr := date.Format("2006-01-02")
+			shiftID := employee.ShiftID
+			roster, isRostered := rosterMap[dateStr]
+			if isRostered {
+				shiftID = roster.ShiftID
+			}
+
+			shift, ok := shiftCache[shiftID]
+			if !ok {
+				if err := db.First(&shift, shiftID).Error; err != nil {
+					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"}
+					return c.JSON(http.StatusInternalServerError, errorResponse)
+				}
+				shiftCache[shiftID] = shift
+			}
+
+			inTime, outTime, _ := shiftTimesForDay(shift, date.Weekday().String())
+			schedule = append(schedule, map[string]interface{}{
+				"date":        dateStr,
+				"day":         date.Weekday().String(),
+				"shift_id":    shift.ID,
+				"shift_name":  shift.ShiftName,
+				"in_time":     inTime,
+				"out_time":    outTime,
+				"is_rostered": isRostered,
+				"remarks":     roster.Remarks,
+			})
+		}
+
+		successResponse := map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Shift roster retrieved successfully",
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"data":       schedule,
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func CreateShiftSwapRequestByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var swapRequest models.ShiftSwapRequest
+		if err := c.Bind(&swapRequest); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if swapRequest.TargetEmployeeID == 0 || swapRequest.SwapDate == "" || swapRequest.Reason == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Target employee, swap date and reason are required"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if len(swapRequest.Reason) < 5 || len(swapRequest.Reason) > 3000 {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Reason must be between 5 and 3000 characters"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if swapRequest.TargetEmployeeID == employee.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot swap shift with yourself"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		swapDate, err := time.Parse("2006-01-02", swapRequest.SwapDate)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid swap date format. Required format: yyyy-mm-dd"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to load timezone"})
+		}
+
+		if swapDate.Format("2006-01-02") < time.Now().In(loc).Format("2006-01-02") {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Swap date cannot be in the past"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var target models.Employee
+		result = db.Where("id = ? AND is_client = ? AND is_exit = ?", swapRequest.TargetEmployeeID, false, false).First(&target)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Target employee not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var pending models.ShiftSwapRequest
+		result = db.Where("status = ? AND swap_date = ? AND (requester_id IN (?) OR target_employee_id IN (?))", "Pending", swapRequest.SwapDate, []uint{employee.ID, target.ID}, []uint{employee.ID, target.ID}).First(&pending)
+		if result.Error == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "A pending shift swap already exists for one of the employees on this date"}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		var requesterShift, targetShift models.Shift
+		if err := db.First(&requesterShift, getEffectiveShiftID(db, employee, swapRequest.SwapDate)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if err := db.First(&targetShift, getEffectiveShiftID(db, target, swapRequest.SwapDate)).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch shift data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if requesterShift.ID == targetShift.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Both employees are already on the same shift for this date"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		currentTime := time.Now()
+		swapRequest.RequesterID = employee.ID
+		swapRequest.RequesterFullName = employee.FirstName + " " + employee.LastName
+		swapRequest.RequesterShiftID = requesterShift.ID
+		swapRequest.RequesterShiftName = requesterShift.ShiftName
+		swapRequest.TargetFullName = target.FirstName + " " + target.LastName
+		swapRequest.TargetShiftID = targetShift.ID
+		swapRequest.TargetShiftName = targetShift.ShiftName
+		swapRequest.SwapDate = swapDate.Format("2006-01-02")
+		swapRequest.Status = "Pending"
+		swapRequest.ReviewedByAdminID = 0
+		swapRequest.ReviewedByAdminName = ""
+		swapRequest.CreatedAt = &currentTime
+
+		if err := db.Create(&swapRequest).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create shift swap request"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		err = helper.SendShiftSwapStatusNotification(target.Email, swapRequest.TargetFullName, swapRequest.RequesterFullName, swapRequest.TargetFullName, swapRequest.SwapDate, swapRequest.Status)
+		if err != nil {
+			fmt.Println("Failed to send shift swap notification:", err)
+		}
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Shift swap request created successfully",
+			"data":    swapRequest,
+		}
+		return c.JSON(http.StatusCreated, successResponse)
+	}
+}
+
+func GetAllShiftSwapRequestsByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, err := strconv.Atoi(c.QueryParam("page"))
+		if err != nil || page <= 0 {
+			page = 1
+		}
+
+		perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+		if err != nil || perPage <= 0 {
+			perPage = 10
+		}
+
+		offset := (page - 1) * perPage
+
+		query := db.Model(&models.ShiftSwapRequest{}).Where("requester_id = ? OR target_employee_id = ?", employee.ID, employee.ID)
+		if status := c.QueryParam("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalCount int64
+		query.Count(&totalCount)
+
+		var swapRequests []models.ShiftSwapRequest
+		query.Order("id DESC").Offset(offset).Limit(perPage).Find(&swapRequests)
+
+		successResponse := map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Shift swap requests retrieved successfully",
+			"data":       swapRequests,
+			"pagination": map[string]interface{}{"total_count": totalCount, "page": page, "per_page": perPage},
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
+
+func DeleteShiftSwapRequestByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokenString := c.Request().Header.Get("Authorization")
+		if tokenString == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		authParts := strings.SplitN(tokenString, " ", 2)
+		if len(authParts) != 2 || authParts[0] != "Bearer" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		tokenString = authParts[1]
+
+		username, err := middleware.VerifyToken(tokenString, secretKey)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			return c.JSON(http.StatusUnauthorized, errorResponse)
+		}
+
+		var employee models.Employee
+		result := db.Where("username = ?", username).First(&employee)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch employee data"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		swapRequestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid shift swap request ID"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var swapRequest models.ShiftSwapRequest
+		result = db.Where("id = ?", swapRequestID).First(&swapRequest)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Shift swap request not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if swapRequest.RequesterID != employee.ID {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Shift swap request does not belong to the employee"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		if swapRequest.Status != "Pending" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Only pending shift swap requests can be cancelled"}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		db.Delete(&swapRequest)
+
+		successResponse := map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Shift swap request deleted successfully",
+		}
+		return c.JSON(http.StatusOK, successResponse)
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"hrsale/helper"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// ServeStorageFile melayani signed URL untuk storage lokal. Backend GCS dan S3 memakai signed URL milik backend masing-masing.
+func ServeStorageFile() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if helper.StorageDriver() != "local" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "File not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		objectName := c.Param("*")
+		if !helper.VerifyLocalFileSignature(objectName, c.QueryParam("expires"), c.QueryParam("signature")) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Invalid or expired file URL"}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		fileStorage, err := helper.NewFileStorage()
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to initialize file storage"}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		reader, err := fileStorage.Open(objectName)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "File not found"}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+		defer reader.Close()
+
+		contentType := mime.TypeByExtension(filepath.Ext(objectName))
+		if contentType == "" {
+			contentType = echo.MIMEOctetStream
+		}
+		return c.Stream(http.StatusOK, contentType, reader)
+	}
+}
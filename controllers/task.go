@@ -53,6 +53,8 @@ func CreateNoteByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		note.Fullname = adminUser.FirstName + " " + adminUser.LastName
+		note.EmployeeID = 0
+		note.AvatarURL = ""
 
 		var existingTask models.Task
 		result = db.First(&existingTask, note.TaskID)
@@ -49,6 +49,8 @@ func CreateNoteByEmployee(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		note.Fullname = employeeUser.FirstName + " " + employeeUser.LastName
+		note.EmployeeID = employeeUser.ID
+		note.AvatarURL = employeeUser.AvatarURL
 
 		var existingTask models.Task
 		result = db.First(&existingTask, note.TaskID)
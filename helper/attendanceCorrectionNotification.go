@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SendAttendanceCorrectionStatusNotification mengirimkan email notifikasi kepada karyawan terkait status koreksi absensi
+func SendAttendanceCorrectionStatusNotification(employeeEmail, fullName, attendanceDate, inTime, outTime, status, reviewNote string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Notifikasi Koreksi Absensi</h1>
+			<p>Halo %s,</p>
+			<p>Permintaan koreksi absensi Anda telah ditinjau dengan rincian berikut:</p>
+			<p>Tanggal: <strong>%s</strong></p>
+			<p>Jam Masuk: <strong>%s</strong></p>
+			<p>Jam Keluar: <strong>%s</strong></p>
+			<p>Status: <strong>%s</strong></p>
+			<p>Catatan: <strong>%s</strong></p>
+			<p>Silakan periksa data absensi Anda di aplikasi HR Harmony.</p>
+			<div class="footer">
+				<p>&copy; %d HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, fullName, attendanceDate, inTime, outTime, status, reviewNote, time.Now().Year())
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := employeeEmail
+	subjectEmail := "Notifikasi Koreksi Absensi"
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
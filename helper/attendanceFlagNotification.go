@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttendanceFlagEmailItem adalah satu baris karyawan yang ditandai pada email ringkasan mingguan
+type AttendanceFlagEmailItem struct {
+	FullName       string
+	DepartmentName string
+	FlagType       string
+	Value          string
+	Threshold      string
+}
+
+// SendAttendanceFlagNotification mengirimkan ringkasan karyawan yang melewati ambang keterlambatan/absensi kepada admin HR
+func SendAttendanceFlagNotification(adminEmail, adminName, periodStart, periodEnd string, items []AttendanceFlagEmailItem) error {
+	var rows strings.Builder
+	for _, item := range items {
+		rows.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>", item.FullName, item.DepartmentName, item.FlagType, item.Value, item.Threshold))
+	}
+
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			table {
+				border-collapse: collapse;
+				width: 100%%;
+			}
+			th, td {
+				border: 1px solid #ddd;
+				padding: 8px;
+				text-align: left;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Laporan Mingguan Absensi</h1>
+			<p>Halo %s,</p>
+			<p>Berikut karyawan yang melewati ambang keterlambatan atau absensi untuk periode <strong>%s</strong> sampai <strong>%s</strong>:</p>
+			<table>
+				<tr><th>Nama</th><th>Departemen</th><th>Jenis</th><th>Nilai</th><th>Ambang</th></tr>
+				%s
+			</table>
+			<p>Silakan tindak lanjuti melalui aplikasi HR Harmony.</p>
+			<div class="footer">
+				<p>&copy; %d HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, adminName, periodStart, periodEnd, rows.String(), time.Now().Year())
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := adminEmail
+	subjectEmail := "Laporan Mingguan Absensi Karyawan"
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
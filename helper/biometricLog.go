@@ -0,0 +1,156 @@
+package helper
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BiometricPunch adalah satu scan sidik jari dari log mesin absensi
+type BiometricPunch struct {
+	PIN       string
+	Timestamp time.Time
+	Line      int
+}
+
+// BiometricParseError mencatat baris log yang tidak dapat dibaca
+type BiometricParseError struct {
+	Line    int    `json:"line"`
+	Content string `json:"content"`
+}
+
+var biometricPINPattern = regexp.MustCompile(`^[0-9A-Za-z]{1,20}$`)
+
+var biometricTimestampLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02-01-2006 15:04:05",
+	"02-01-2006 15:04",
+	"2006-01-02T15:04:05",
+}
+
+// biometricHeaderPINColumn mencari kolom PIN pada baris header ekspor CSV, -1 jika bukan header
+func biometricHeaderPINColumn(fields []string) int {
+	for i, field := range fields {
+		switch strings.ToLower(strings.TrimSuffix(field, ".")) {
+		case "pin", "user id", "userid", "user_id", "ac-no", "ac no", "enroll no", "enrollnumber", "badge":
+			return i
+		}
+	}
+	return -1
+}
+
+func parseBiometricTimestamp(value string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range biometricTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitBiometricLine memecah baris berdasarkan tab (format attlog ZKTeco), koma atau titik koma
+func splitBiometricLine(line string) []string {
+	separator := "\t"
+	switch {
+	case strings.Contains(line, "\t"):
+	case strings.Contains(line, ";"):
+		separator = ";"
+	case strings.Contains(line, ","):
+		separator = ","
+	}
+
+	fields := strings.Split(line, separator)
+	for i := range fields {
+		fields[i] = strings.Trim(strings.TrimSpace(fields[i]), `"`)
+	}
+	return fields
+}
+
+// ParseBiometricLog membaca log mesin sidik jari (attlog ZKTeco berformat tab atau ekspor CSV).
+// Kolom PIN diambil dari header CSV jika ada, selain itu kolom pertama yang berisi PIN. Kolom
+// tanggal-waktu (gabungan atau terpisah) dideteksi otomatis; baris yang tidak dikenali dilaporkan.
+func ParseBiometricLog(r io.Reader, loc *time.Location) ([]BiometricPunch, []BiometricParseError, error) {
+	var punches []BiometricPunch
+	var parseErrors []BiometricParseError
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	pinColumn := -1
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+
+		fields := splitBiometricLine(line)
+		if pinColumn < 0 {
+			if column := biometricHeaderPINColumn(fields); column >= 0 {
+				pinColumn = column
+				continue
+			}
+		}
+
+		pin := ""
+		if pinColumn >= 0 && pinColumn < len(fields) && biometricPINPattern.MatchString(fields[pinColumn]) {
+			pin = strings.TrimLeft(fields[pinColumn], "0")
+			if pin == "" {
+				pin = "0"
+			}
+		}
+
+		var timestamp time.Time
+		found := false
+		for i, field := range fields {
+			if i == pinColumn {
+				continue
+			}
+			if pin == "" && pinColumn < 0 && biometricPINPattern.MatchString(field) {
+				pin = strings.TrimLeft(field, "0")
+				if pin == "" {
+					pin = "0"
+				}
+				continue
+			}
+			if t, ok := parseBiometricTimestamp(field, loc); ok {
+				timestamp, found = t, true
+				break
+			}
+			// Tanggal dan jam pada kolom terpisah
+			if i+1 < len(fields) {
+				if t, ok := parseBiometricTimestamp(field+" "+fields[i+1], loc); ok {
+					timestamp, found = t, true
+					break
+				}
+			}
+		}
+
+		if pin == "" || !found {
+			parseErrors = append(parseErrors, BiometricParseError{Line: lineNumber, Content: line})
+			continue
+		}
+
+		punches = append(punches, BiometricPunch{PIN: pin, Timestamp: timestamp, Line: lineNumber})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	sort.SliceStable(punches, func(i, j int) bool {
+		if punches[i].PIN != punches[j].PIN {
+			return punches[i].PIN < punches[j].PIN
+		}
+		return punches[i].Timestamp.Before(punches[j].Timestamp)
+	})
+
+	return punches, parseErrors, nil
+}
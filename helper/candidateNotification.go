@@ -0,0 +1,173 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+)
+
+// SendCandidateApplicationReceived mengirimkan email konfirmasi kepada pelamar setelah lamaran diterima
+func SendCandidateApplicationReceived(candidateEmail, fullName, jobTitle string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Lamaran Anda Telah Diterima</h1>
+			<p>Halo %s,</p>
+			<p>Terima kasih telah melamar posisi <strong>%s</strong>.</p>
+			<p>Tim rekrutmen kami akan meninjau lamaran Anda dan menghubungi Anda untuk tahap selanjutnya.</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, fullName, jobTitle)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := candidateEmail
+	subjectEmail := "Lamaran Diterima: " + jobTitle
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendCandidateStageNotification mengirimkan email kepada kandidat setiap kali tahap rekrutmennya berubah
+func SendCandidateStageNotification(candidateEmail, fullName, jobTitle, stageName, message string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Perkembangan Lamaran Anda</h1>
+			<p>Halo %s,</p>
+			<p>Terdapat perkembangan pada lamaran Anda untuk posisi <strong>%s</strong>.</p>
+			<p>Tahap Saat Ini: <strong>%s</strong></p>
+			<p>%s</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, fullName, jobTitle, stageName, message)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := candidateEmail
+	subjectEmail := "Perkembangan Lamaran: " + jobTitle
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
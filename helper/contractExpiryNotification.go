@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SendContractExpiryReminder mengirimkan pengingat kepada HR atau manager bahwa kontrak karyawan akan berakhir
+func SendContractExpiryReminder(recipientEmail, recipientName, employeeName, contractType, endDate string, daysRemaining int) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Pengingat Kontrak Berakhir</h1>
+			<p>Halo %s,</p>
+			<p>Kontrak kerja berikut akan berakhir dalam <strong>%d hari</strong>:</p>
+			<p>Karyawan: <strong>%s</strong></p>
+			<p>Jenis Kontrak: <strong>%s</strong></p>
+			<p>Tanggal Berakhir: <strong>%s</strong></p>
+			<p>Silakan tinjau apakah kontrak akan diperpanjang melalui aplikasi HR Harmony.</p>
+			<div class="footer">
+				<p>&copy; %d HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, recipientName, daysRemaining, employeeName, contractType, endDate, time.Now().Year())
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := fmt.Sprintf("Kontrak %s Berakhir dalam %d Hari", employeeName, daysRemaining)
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
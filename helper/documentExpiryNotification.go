@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SendDocumentExpiryReminder mengirimkan pengingat kepada karyawan atau HR bahwa dokumen karyawan akan kedaluwarsa
+func SendDocumentExpiryReminder(recipientEmail, recipientName, employeeName, documentTitle, expiryDate string, daysRemaining int) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Pengingat Dokumen Kedaluwarsa</h1>
+			<p>Halo %s,</p>
+			<p>Dokumen berikut akan kedaluwarsa dalam <strong>%d hari</strong>:</p>
+			<p>Karyawan: <strong>%s</strong></p>
+			<p>Dokumen: <strong>%s</strong></p>
+			<p>Tanggal Kedaluwarsa: <strong>%s</strong></p>
+			<p>Silakan perbarui dokumen tersebut dan unggah versi terbarunya melalui aplikasi HR Harmony.</p>
+			<div class="footer">
+				<p>&copy; %d HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, recipientName, daysRemaining, employeeName, documentTitle, expiryDate, time.Now().Year())
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := fmt.Sprintf("Dokumen %s Kedaluwarsa dalam %d Hari", documentTitle, daysRemaining)
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
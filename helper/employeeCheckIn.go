@@ -171,3 +171,90 @@ func SendAttendanceCheckoutNotification(employeeEmail, fullName, checkoutTime, t
 
 	return nil
 }
+
+// SendMissingCheckoutNotification mengirimkan email notifikasi kepada karyawan yang lupa melakukan checkout
+func SendMissingCheckoutNotification(employeeEmail, fullName, attendanceDate, checkoutTime string, isAutoClosed bool) error {
+	information := "Absensi Anda ditandai sebagai <strong>Missing Checkout</strong>. Silakan ajukan koreksi absensi melalui aplikasi HR Harmony."
+	if isAutoClosed {
+		information = fmt.Sprintf("Sistem telah melakukan checkout otomatis sesuai jam akhir shift pada waktu: <strong>%s</strong>. Ajukan koreksi absensi jika jam tersebut tidak sesuai.", checkoutTime)
+	}
+
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Notifikasi Checkout Terlewat</h1>
+			<p>Halo, <strong>%s</strong>,</p>
+			<p>Anda belum melakukan checkout untuk absensi tanggal: <strong>%s</strong></p>
+			<p>%s</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, fullName, attendanceDate, information)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := employeeEmail
+	subject := "Notifikasi Checkout Terlewat"
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/jung-kurt/gofpdf"
+	"hrsale/models"
+	"time"
+)
+
+// GenerateFinalSettlementPDF membuat dokumen rincian hak akhir karyawan dengan format yang sama seperti slip gaji
+func GenerateFinalSettlementPDF(settlement models.FinalSettlement) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Tambahkan logo
+	logoPath := "helper/logo.png"
+	pdf.ImageOptions(
+		logoPath, 10, 10, 30, 0, false,
+		gofpdf.ImageOptions{ReadDpi: true, ImageType: "PNG"},
+		0, "",
+	)
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.SetXY(50, 15)
+	pdf.SetTextColor(0, 102, 204)
+	pdf.Cell(100, 10, "HR Harmony")
+	pdf.Ln(20)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Final Settlement Statement", "", 1, "C", false, 0, "")
+	if settlement.Status != "Finalized" {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.CellFormat(0, 6, "DRAFT - not yet finalized", "", 1, "C", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Informasi Karyawan
+	serviceLength := fmt.Sprintf("%d years %d months", settlement.ServiceYears, settlement.ServiceMonths)
+	details := [][2]string{
+		{"Employee Name", settlement.FullName},
+		{"Termination Reason", settlement.TerminationReason},
+		{"Join Date", settlement.JoinDate},
+		{"Last Working Date", settlement.LastWorkingDate},
+		{"Length of Service", serviceLength},
+		{"Monthly Salary", FormatToIDR(settlement.MonthlySalary)},
+	}
+	for _, detail := range details {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(50, 7, detail[0]+":")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(120, 7, tr(detail[1]))
+		pdf.Ln(7)
+	}
+	pdf.Ln(6)
+
+	row := func(description string, amount float64) {
+		pdf.SetFont("Arial", "", 11)
+		pdf.SetFillColor(255, 255, 255)
+		pdf.CellFormat(120, 8, tr(description), "1", 0, "", false, 0, "")
+		pdf.CellFormat(60, 8, FormatToIDR(amount), "1", 1, "R", false, 0, "")
+	}
+	header := func(title string) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFillColor(200, 200, 200)
+		pdf.CellFormat(120, 8, title, "1", 0, "C", true, 0, "")
+		pdf.CellFormat(60, 8, "Amount", "1", 1, "C", true, 0, "")
+	}
+	subtotal := func(title string, amount float64) {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(120, 8, title, "1", 0, "", true, 0, "")
+		pdf.CellFormat(60, 8, FormatToIDR(amount), "1", 1, "R", true, 0, "")
+	}
+
+	// Pendapatan
+	header("Earnings")
+	salaryLabel := fmt.Sprintf("Prorated Salary (%d/%d days)", settlement.DaysWorked, settlement.DaysInMonth)
+	if settlement.SalaryAlreadyPaid {
+		salaryLabel += " - paid through payroll"
+	}
+	row(salaryLabel, settlement.ProratedSalary)
+	row(fmt.Sprintf("Unused Leave Encashment (%.1f days)", settlement.UnusedLeaveDays), settlement.LeaveEncashment)
+	row(fmt.Sprintf("Severance Pay / UP (%.2f x %.0f months)", settlement.SeveranceMultiplier, settlement.SeveranceMonths), settlement.SeverancePay)
+	row(fmt.Sprintf("Service Award / UPMK (%.0f months)", settlement.ServiceAwardMonths), settlement.ServiceAwardPay)
+	if settlement.ContractCompensation > 0 {
+		row("PKWT Compensation", settlement.ContractCompensation)
+	}
+	if settlement.OtherEarnings > 0 {
+		row("Other Earnings", settlement.OtherEarnings)
+	}
+	subtotal("Gross Amount", settlement.GrossAmount)
+	pdf.Ln(4)
+
+	// Potongan
+	header("Deductions")
+	row("Outstanding Loan Recovery", settlement.LoanRecovery)
+	row("Outstanding Salary Advance Recovery", settlement.AdvanceRecovery)
+	if settlement.OtherDeductions > 0 {
+		row("Other Deductions", settlement.OtherDeductions)
+	}
+	subtotal("Total Deductions", settlement.TotalDeductions)
+	pdf.Ln(4)
+
+	// Tambahkan Total
+	pdf.SetFont("Arial", "B", 12)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(120, 10, "Net Final Settlement", "1", 0, "", true, 0, "")
+	pdf.CellFormat(60, 10, FormatToIDR(settlement.NetAmount), "1", 1, "R", true, 0, "")
+
+	if settlement.Notes != "" {
+		pdf.Ln(6)
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, tr("Notes: "+settlement.Notes), "", "L", false)
+	}
+
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.MultiCell(0, 5, "Severance and service award are calculated according to PP 35/2021 (implementing regulation of UU Cipta Kerja).", "", "L", false)
+	if settlement.FinalizedAt != nil {
+		pdf.MultiCell(0, 5, "Finalized on "+settlement.FinalizedAt.Format("02 January 2006"), "", "L", false)
+	} else {
+		pdf.MultiCell(0, 5, "Generated on "+time.Now().Format("02 January 2006"), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+)
+
+// SendHelpdeskReplyNotification mengirimkan email kepada pihak lain pada tiket helpdesk setiap ada balasan baru
+func SendHelpdeskReplyNotification(recipientEmail, recipientName, subject, authorName, message, status string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Balasan Baru pada Tiket Helpdesk</h1>
+			<p>Halo %s,</p>
+			<p>Terdapat balasan baru pada tiket helpdesk dengan rincian sebagai berikut:</p>
+			<p>Subjek: <strong>%s</strong></p>
+			<p>Dari: <strong>%s</strong></p>
+			<p>Pesan: <strong>%s</strong></p>
+			<p>Status: <strong>%s</strong></p>
+			<p>Silakan masuk ke aplikasi HR Harmony untuk membalas tiket ini.</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, recipientName, subject, authorName, message, status)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := "Balasan Baru pada Tiket Helpdesk: " + subject
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
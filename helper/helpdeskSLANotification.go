@@ -0,0 +1,181 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"os"
+	"strconv"
+)
+
+// SendHelpdeskAssignmentNotification mengirimkan email kepada admin yang ditugaskan menangani tiket helpdesk
+func SendHelpdeskAssignmentNotification(recipientEmail, recipientName, subject, priority, employeeName, resolutionDueAt string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Penugasan Tiket Helpdesk</h1>
+			<p>Halo %s,</p>
+			<p>Anda ditugaskan untuk menangani tiket helpdesk berikut:</p>
+			<p>Subjek: <strong>%s</strong></p>
+			<p>Prioritas: <strong>%s</strong></p>
+			<p>Karyawan: <strong>%s</strong></p>
+			<p>Batas Waktu Penyelesaian: <strong>%s</strong></p>
+			<p>Silakan segera menanggapi tiket ini agar tidak melewati batas SLA.</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, recipientName, subject, priority, employeeName, resolutionDueAt)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := "Penugasan Tiket Helpdesk: " + subject
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendHelpdeskEscalationNotification mengirimkan email eskalasi ketika tiket helpdesk melewati batas SLA
+func SendHelpdeskEscalationNotification(recipientEmail, recipientName, subject, priority, breachType, dueAt, assignedAdminName string, escalationLevel int) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Eskalasi Tiket Helpdesk</h1>
+			<p>Halo %s,</p>
+			<p>Tiket helpdesk berikut telah melewati batas SLA dan memerlukan perhatian segera:</p>
+			<p>Subjek: <strong>%s</strong></p>
+			<p>Prioritas: <strong>%s</strong></p>
+			<p>Pelanggaran SLA: <strong>%s</strong></p>
+			<p>Batas Waktu: <strong>%s</strong></p>
+			<p>Ditangani Oleh: <strong>%s</strong></p>
+			<p>Tingkat Eskalasi: <strong>%d</strong></p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, recipientName, subject, priority, breachType, dueAt, assignedAdminName, escalationLevel)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := "Eskalasi Tiket Helpdesk: " + subject
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	ProfilePhotoStandardSize  = 512 // sisi terpanjang foto standar
+	ProfilePhotoThumbnailSize = 128 // thumbnail berbentuk persegi
+
+	// Batas jumlah piksel sebelum decode, file kecil berdimensi besar bisa memakan memori hingga gigabyte
+	ProfilePhotoMaxPixels = 25_000_000
+)
+
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// ResizeProfilePhoto mengubah foto (JPEG, PNG atau GIF) menjadi ukuran standar dan thumbnail persegi dalam format JPEG
+func ResizeProfilePhoto(data []byte) ([]byte, []byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > ProfilePhotoMaxPixels {
+		return nil, nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Foto standar: pertahankan rasio, perkecil hanya jika lebih besar dari ukuran standar
+	standardWidth, standardHeight := width, height
+	if width > ProfilePhotoStandardSize || height > ProfilePhotoStandardSize {
+		if width >= height {
+			standardWidth = ProfilePhotoStandardSize
+			standardHeight = height * ProfilePhotoStandardSize / width
+		} else {
+			standardHeight = ProfilePhotoStandardSize
+			standardWidth = width * ProfilePhotoStandardSize / height
+		}
+	}
+	standard := scaleImage(src, bounds, max(standardWidth, 1), max(standardHeight, 1))
+
+	// Thumbnail: potong bagian tengah menjadi persegi lalu perkecil
+	side := min(width, height)
+	cropX := bounds.Min.X + (width-side)/2
+	cropY := bounds.Min.Y + (height-side)/2
+	thumbnail := scaleImage(src, image.Rect(cropX, cropY, cropX+side, cropY+side), ProfilePhotoThumbnailSize, ProfilePhotoThumbnailSize)
+
+	var standardBuf, thumbnailBuf bytes.Buffer
+	if err := jpeg.Encode(&standardBuf, standard, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, nil, err
+	}
+	if err := jpeg.Encode(&thumbnailBuf, thumbnail, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, nil, err
+	}
+
+	return standardBuf.Bytes(), thumbnailBuf.Bytes(), nil
+}
+
+// scaleImage mengubah ukuran area rect dari src menggunakan rata-rata piksel (box filter)
+func scaleImage(src image.Image, rect image.Rectangle, dstWidth, dstHeight int) *image.RGBA {
+	// Latar putih agar area transparan (PNG/GIF) tidak menjadi hitam saat disimpan sebagai JPEG
+	rgba := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), src, rect.Min, draw.Over)
+
+	srcWidth, srcHeight := rect.Dx(), rect.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := y * srcHeight / dstHeight
+		y1 := max((y+1)*srcHeight/dstHeight, y0+1)
+		for x := 0; x < dstWidth; x++ {
+			x0 := x * srcWidth / dstWidth
+			x1 := max((x+1)*srcWidth/dstWidth, x0+1)
+
+			var r, g, b, a, count uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			dstOffset := dst.PixOffset(x, y)
+			dst.Pix[dstOffset] = uint8(r / count)
+			dst.Pix[dstOffset+1] = uint8(g / count)
+			dst.Pix[dstOffset+2] = uint8(b / count)
+			dst.Pix[dstOffset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
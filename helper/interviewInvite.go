@@ -0,0 +1,189 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InterviewInvite berisi data yang dibutuhkan untuk membuat undangan kalender (.ics)
+type InterviewInvite struct {
+	UID             string
+	Summary         string
+	Description     string
+	Location        string
+	Start           time.Time
+	DurationMinutes int
+	OrganizerEmail  string
+	Attendees       []string
+	Sequence        int
+	Cancelled       bool
+}
+
+// icsEscape meng-escape karakter khusus sesuai RFC 5545
+func icsEscape(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\r\n", "\\n", "\n", "\\n")
+	return replacer.Replace(value)
+}
+
+// BuildInterviewICS membuat isi file .ics untuk undangan atau pembatalan wawancara
+func BuildInterviewICS(invite InterviewInvite) []byte {
+	const icsTimeFormat = "20060102T150405Z"
+
+	method := "REQUEST"
+	status := "CONFIRMED"
+	if invite.Cancelled {
+		method = "CANCEL"
+		status = "CANCELLED"
+	}
+
+	end := invite.Start.Add(time.Duration(invite.DurationMinutes) * time.Minute)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//HR Harmony//Interview Scheduler//ID",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + method,
+		"BEGIN:VEVENT",
+		"UID:" + invite.UID,
+		"DTSTAMP:" + time.Now().UTC().Format(icsTimeFormat),
+		"DTSTART:" + invite.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + end.UTC().Format(icsTimeFormat),
+		"SEQUENCE:" + strconv.Itoa(invite.Sequence),
+		"STATUS:" + status,
+		"SUMMARY:" + icsEscape(invite.Summary),
+		"DESCRIPTION:" + icsEscape(invite.Description),
+		"LOCATION:" + icsEscape(invite.Location),
+	}
+	if invite.OrganizerEmail != "" {
+		lines = append(lines, "ORGANIZER;CN=HR Harmony:mailto:"+invite.OrganizerEmail)
+	}
+	for _, attendee := range invite.Attendees {
+		lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+attendee)
+	}
+	if !invite.Cancelled {
+		lines = append(lines,
+			"BEGIN:VALARM",
+			"TRIGGER:-PT30M",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:"+icsEscape(invite.Summary),
+			"END:VALARM",
+		)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// SendInterviewInvitation mengirimkan undangan atau pembatalan wawancara dengan lampiran .ics
+func SendInterviewInvitation(recipientEmail, recipientName, title, jobTitle, schedule, location, meetingLink string, cancelled bool, icsContent []byte) error {
+	heading := "Undangan Wawancara"
+	intro := "Anda diundang untuk mengikuti wawancara berikut. Silakan tambahkan lampiran undangan ke kalender Anda."
+	if cancelled {
+		heading = "Wawancara Dibatalkan"
+		intro = "Wawancara berikut telah dibatalkan. Lampiran undangan akan menghapus jadwal dari kalender Anda."
+	}
+	if meetingLink == "" {
+		meetingLink = "-"
+	}
+
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>%s</h1>
+			<p>Halo %s,</p>
+			<p>%s</p>
+			<p>Wawancara: <strong>%s</strong></p>
+			<p>Posisi: <strong>%s</strong></p>
+			<p>Jadwal: <strong>%s</strong></p>
+			<p>Lokasi: <strong>%s</strong></p>
+			<p>Tautan Meeting: <strong>%s</strong></p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, heading, recipientName, intro, title, jobTitle, schedule, location, meetingLink)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := heading + ": " + title
+
+	method := "REQUEST"
+	if cancelled {
+		method = "CANCEL"
+	}
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+	m.Attach("invite.ics",
+		gomail.SetHeader(map[string][]string{"Content-Type": {"text/calendar; charset=UTF-8; method=" + method}}),
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(icsContent)
+			return err
+		}),
+	)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
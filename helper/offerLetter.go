@@ -0,0 +1,241 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-gomail/gomail"
+	"github.com/jung-kurt/gofpdf"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateLetterPDF menghasilkan PDF surat penawaran/kontrak; acknowledgement diisi setelah penerima menyetujui secara elektronik.
+// issuedAt adalah tanggal terbit surat sehingga PDF yang dibuat ulang tetap mencetak tanggal yang sama
+func GenerateLetterPDF(letterNumber, subject, content, acknowledgement string, issuedAt time.Time) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Tambahkan logo
+	logoPath := "helper/logo.png"
+	pdf.ImageOptions(
+		logoPath, 10, 10, 30, 0, false,
+		gofpdf.ImageOptions{ReadDpi: true, ImageType: "PNG"},
+		0, "",
+	)
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.SetXY(50, 15)
+	pdf.SetTextColor(0, 102, 204)
+	pdf.Cell(100, 10, "HR Harmony")
+	pdf.Ln(20)
+
+	// Nomor dan perihal surat
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "", 11)
+	pdf.Cell(30, 7, "Number")
+	pdf.Cell(100, 7, ": "+tr(letterNumber))
+	pdf.Ln(7)
+	pdf.Cell(30, 7, "Date")
+	pdf.Cell(100, 7, ": "+issuedAt.Format("02 January 2006"))
+	pdf.Ln(7)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(30, 7, "Subject")
+	pdf.Cell(100, 7, ": "+tr(subject))
+	pdf.Ln(14)
+
+	// Isi surat
+	pdf.SetFont("Arial", "", 11)
+	for _, paragraph := range strings.Split(content, "\n") {
+		pdf.MultiCell(0, 6, tr(paragraph), "", "L", false)
+	}
+
+	// Persetujuan elektronik
+	if acknowledgement != "" {
+		pdf.Ln(10)
+		pdf.SetFont("Arial", "I", 10)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.MultiCell(0, 6, tr(acknowledgement), "1", "L", true)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SendOfferLetterEmail mengirimkan surat penawaran/kontrak dalam bentuk PDF beserta tautan untuk menerima atau menolak
+func SendOfferLetterEmail(recipientEmail, recipientName, subject, letterType, responseURL, expiryDate string, pdfData []byte, fileName string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>%s</h1>
+			<p>Halo %s,</p>
+			<p>Terlampir %s dari HR Harmony untuk Anda. Silakan baca dokumen terlampir dengan seksama.</p>
+			<p>Untuk menerima atau menolak, silakan buka tautan berikut sebelum <strong>%s</strong>:</p>
+			<p><a href="%s">%s</a></p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, subject, recipientName, letterType, expiryDate, responseURL, responseURL)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := recipientEmail
+	subjectEmail := subject
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+	m.Attach(fileName, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(pdfData)
+		return err
+	}))
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendOfferLetterResponseNotification memberi tahu HR bahwa penerima surat telah menerima atau menolak
+func SendOfferLetterResponseNotification(adminEmail, adminName, recipientName, letterType, letterNumber, status, reason string) error {
+	// Konstruksi isi email
+	emailBody := fmt.Sprintf(`
+	<html>
+	<head>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				background-color: #f4f4f4;
+				margin: 0;
+				padding: 20px;
+			}
+			.container {
+				background-color: #fff;
+				padding: 30px;
+				border-radius: 5px;
+				box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+			}
+			h1 {
+				color: #333;
+			}
+			p {
+				font-size: 16px;
+				line-height: 1.6;
+				margin: 10px 0;
+			}
+			strong {
+				font-weight: bold;
+			}
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<h1>Respon %s</h1>
+			<p>Halo %s,</p>
+			<p><strong>%s</strong> telah memberikan respon atas %s nomor <strong>%s</strong>.</p>
+			<p>Status: <strong>%s</strong></p>
+			<p>Alasan: %s</p>
+			<div class="footer">
+				<p>&copy; 2024 HR Harmony. All rights reserved.</p>
+			</div>
+		</div>
+	</body>
+	</html>
+	`, letterType, adminName, recipientName, letterType, letterNumber, status, reason)
+
+	// Set konfigurasi email
+	smtpServer := os.Getenv("SMTP_SERVER")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	sender := smtpUsername
+	recipient := adminEmail
+	subjectEmail := "Respon " + letterType + ": " + recipientName
+
+	// Buat pesan email
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender)
+	m.SetHeader("To", recipient)
+	m.SetHeader("Subject", subjectEmail)
+	m.SetBody("text/html", emailBody)
+
+	// Konfigurasi dialer
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
+
+	// Kirim email
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -41,6 +41,7 @@ type EmployeeResponse struct {
 	PaySlipType   string  `json:"pay_slip_type"`
 	IsActive      bool    `json:"is_active" gorm:"default:true"`
 	PaidStatus    bool    `json:"paid_status" gorm:"default:false"`
+	AvatarURL     string  `json:"avatar_url"`
 	MaritalStatus string  `json:"marital_status"`
 	Religion      string  `json:"religion"`
 	BloodGroup    string  `json:"blood_group"`
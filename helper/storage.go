@@ -0,0 +1,163 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileStorage adalah abstraksi penyimpanan file sehingga fitur upload tidak bergantung pada satu backend.
+// Backend dipilih melalui env STORAGE_DRIVER: local, gcs atau s3 (MinIO/S3 compatible).
+type FileStorage interface {
+	Upload(objectName string, data []byte, contentType string) error
+	Open(objectName string) (io.ReadCloser, error)
+	Delete(objectName string) error
+	// SignedURL menghasilkan URL download sementara yang berlaku selama expiry
+	SignedURL(objectName string, expiry time.Duration) (string, error)
+}
+
+// StorageDriver mengembalikan backend yang dikonfigurasi. Jika STORAGE_DRIVER kosong,
+// gcs dipakai saat CREDENTIALS tersedia dan local untuk pengembangan tanpa kredensial cloud.
+func StorageDriver() string {
+	driver := strings.ToLower(os.Getenv("STORAGE_DRIVER"))
+	if driver == "" {
+		if os.Getenv("CREDENTIALS") != "" {
+			return "gcs"
+		}
+		return "local"
+	}
+	if driver == "minio" {
+		return "s3"
+	}
+	return driver
+}
+
+// NewFileStorage mengembalikan backend penyimpanan sesuai konfigurasi
+func NewFileStorage() (FileStorage, error) {
+	switch StorageDriver() {
+	case "local":
+		return newLocalStorage(), nil
+	case "gcs":
+		return newGCSStorage(), nil
+	case "s3":
+		return newS3Storage()
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", os.Getenv("STORAGE_DRIVER"))
+	}
+}
+
+// DetectContentType menentukan content type dari isi file, lalu dari ekstensi jika isi file tidak dikenali
+func DetectContentType(data []byte, fileName string) string {
+	detected := http.DetectContentType(data)
+	if detected == "application/octet-stream" || detected == "application/zip" || strings.HasPrefix(detected, "text/plain") {
+		if byExtension := mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName))); byExtension != "" {
+			return byExtension
+		}
+	}
+	return detected
+}
+
+// cleanObjectName menormalkan nama objek dan menolak path traversal
+func cleanObjectName(objectName string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+objectName), "/")
+	if cleaned == "" || cleaned != strings.TrimPrefix(objectName, "/") {
+		return "", errors.New("invalid object name")
+	}
+	return cleaned, nil
+}
+
+// storageSigningKey dipakai untuk menandatangani URL file lokal, default ke SECRET_KEY
+func storageSigningKey() []byte {
+	if key := os.Getenv("STORAGE_SIGNING_KEY"); key != "" {
+		return []byte(key)
+	}
+	return []byte(os.Getenv("SECRET_KEY"))
+}
+
+func localFileSignature(objectName, expires string) string {
+	mac := hmac.New(sha256.New, storageSigningKey())
+	mac.Write([]byte(objectName + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyLocalFileSignature memeriksa tanda tangan dan masa berlaku URL file lokal
+func VerifyLocalFileSignature(objectName, expires, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(localFileSignature(objectName, expires)))
+}
+
+// localStorage menyimpan file di filesystem (env LOCAL_STORAGE_DIR, default "uploads")
+type localStorage struct {
+	rootDir string
+	baseURL string
+}
+
+func newLocalStorage() *localStorage {
+	rootDir := os.Getenv("LOCAL_STORAGE_DIR")
+	if rootDir == "" {
+		rootDir = "uploads"
+	}
+	return &localStorage{rootDir: rootDir, baseURL: strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/")}
+}
+
+func (s *localStorage) filePath(objectName string) (string, error) {
+	cleaned, err := cleanObjectName(objectName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.rootDir, filepath.FromSlash(cleaned)), nil
+}
+
+func (s *localStorage) Upload(objectName string, data []byte, contentType string) error {
+	filePath, err := s.filePath(objectName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
+
+func (s *localStorage) Open(objectName string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(objectName)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+func (s *localStorage) Delete(objectName string) error {
+	filePath, err := s.filePath(objectName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL untuk storage lokal mengarah ke endpoint /files yang memverifikasi tanda tangan HMAC
+func (s *localStorage) SignedURL(objectName string, expiry time.Duration) (string, error) {
+	cleaned, err := cleanObjectName(objectName)
+	if err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	return fmt.Sprintf("%s/files/%s?expires=%s&signature=%s", s.baseURL, cleaned, expires, localFileSignature(cleaned, expires)), nil
+}
@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"bytes"
+	"cloud.google.com/go/storage"
+	"context"
+	"google.golang.org/api/option"
+	"io"
+	"os"
+	"time"
+)
+
+// gcsStorage menyimpan file di Google Cloud Storage (env GCS_BUCKET, kredensial dari CREDENTIALS)
+type gcsStorage struct {
+	bucketName string
+}
+
+func newGCSStorage() *gcsStorage {
+	bucketName := os.Getenv("GCS_BUCKET")
+	if bucketName == "" {
+		bucketName = "destimate"
+	}
+	return &gcsStorage{bucketName: bucketName}
+}
+
+func (s *gcsStorage) client(ctx context.Context) (*storage.Client, error) {
+	credentialsBytes, err := decodeBase64Credential()
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewClient(ctx, option.WithCredentialsJSON(credentialsBytes))
+}
+
+func (s *gcsStorage) Upload(objectName string, data []byte, contentType string) error {
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	wc := client.Bucket(s.bucketName).Object(objectName).NewWriter(ctx)
+	wc.ContentType = contentType
+	if _, err := io.Copy(wc, bytes.NewReader(data)); err != nil {
+		wc.Close()
+		return err
+	}
+	return wc.Close()
+}
+
+func (s *gcsStorage) Open(objectName string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := client.Bucket(s.bucketName).Object(objectName).NewReader(ctx)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &gcsObjectReader{Reader: reader, client: client}, nil
+}
+
+func (s *gcsStorage) Delete(objectName string) error {
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.Bucket(s.bucketName).Object(objectName).Delete(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+	return err
+}
+
+// SignedURL memakai service account pada CREDENTIALS untuk menandatangani URL V4
+func (s *gcsStorage) SignedURL(objectName string, expiry time.Duration) (string, error) {
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	return client.Bucket(s.bucketName).SignedURL(objectName, &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  "GET",
+		Expires: time.Now().Add(expiry),
+	})
+}
+
+// gcsObjectReader menutup client GCS bersamaan dengan reader objek
+type gcsObjectReader struct {
+	*storage.Reader
+	client *storage.Client
+}
+
+func (r *gcsObjectReader) Close() error {
+	err := r.Reader.Close()
+	r.client.Close()
+	return err
+}
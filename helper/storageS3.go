@@ -0,0 +1,204 @@
+package helper
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// s3Storage menyimpan file di S3 atau MinIO menggunakan AWS Signature V4 dan path-style URL.
+// Konfigurasi: S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY dan S3_USE_SSL.
+type s3Storage struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	scheme    string
+}
+
+func newS3Storage() (*s3Storage, error) {
+	s := &s3Storage{
+		endpoint:  strings.TrimSuffix(os.Getenv("S3_ENDPOINT"), "/"),
+		region:    os.Getenv("S3_REGION"),
+		bucket:    os.Getenv("S3_BUCKET"),
+		accessKey: os.Getenv("S3_ACCESS_KEY"),
+		secretKey: os.Getenv("S3_SECRET_KEY"),
+		scheme:    "https",
+	}
+	if s.endpoint == "" || s.bucket == "" || s.accessKey == "" || s.secretKey == "" {
+		return nil, errors.New("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY are required for the s3 storage driver")
+	}
+	if s.region == "" {
+		s.region = "us-east-1"
+	}
+	if useSSL, err := strconv.ParseBool(os.Getenv("S3_USE_SSL")); err == nil && !useSSL {
+		s.scheme = "http"
+	}
+	// Endpoint boleh ditulis lengkap dengan skema, misalnya http://localhost:9000
+	if strings.HasPrefix(s.endpoint, "http://") || strings.HasPrefix(s.endpoint, "https://") {
+		parts := strings.SplitN(s.endpoint, "://", 2)
+		s.scheme, s.endpoint = parts[0], parts[1]
+	}
+	return s, nil
+}
+
+// s3URIEncode meng-encode string sesuai aturan SigV4 (hanya karakter unreserved yang tidak di-encode)
+func s3URIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
+			encoded.WriteByte(b)
+		} else {
+			encoded.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return encoded.String()
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func s3SHA256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *s3Storage) canonicalURI(objectName string) (string, error) {
+	cleaned, err := cleanObjectName(objectName)
+	if err != nil {
+		return "", err
+	}
+	return "/" + s3URIEncode(s.bucket, true) + "/" + s3URIEncode(cleaned, false), nil
+}
+
+func (s *s3Storage) credentialScope(dateStamp string) string {
+	return dateStamp + "/" + s.region + "/s3/aws4_request"
+}
+
+func (s *s3Storage) signature(dateStamp, stringToSign string) string {
+	signingKey := s3HMAC([]byte("AWS4"+s.secretKey), dateStamp)
+	signingKey = s3HMAC(signingKey, s.region)
+	signingKey = s3HMAC(signingKey, "s3")
+	signingKey = s3HMAC(signingKey, "aws4_request")
+	return hex.EncodeToString(s3HMAC(signingKey, stringToSign))
+}
+
+// do mengirim request yang ditandatangani melalui header Authorization
+func (s *s3Storage) do(method, objectName string, body []byte, contentType string) (*http.Response, error) {
+	canonicalURI, err := s.canonicalURI(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	payloadHash := s3SHA256Hex(body)
+
+	canonicalHeaders := "host:" + s.endpoint + "\n" + "x-amz-content-sha256:" + payloadHash + "\n" + "x-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{method, canonicalURI, "", canonicalHeaders, signedHeaders, payloadHash}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, s.credentialScope(dateStamp), s3SHA256Hex([]byte(canonicalRequest))}, "\n")
+
+	req, err := http.NewRequest(method, s.scheme+"://"+s.endpoint+canonicalURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, s.credentialScope(dateStamp), signedHeaders, s.signature(dateStamp, stringToSign)))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+func (s *s3Storage) Upload(objectName string, data []byte, contentType string) error {
+	resp, err := s.do(http.MethodPut, objectName, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("s3 upload failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *s3Storage) Open(objectName string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, objectName, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 download failed with status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Storage) Delete(objectName string) error {
+	resp, err := s.do(http.MethodDelete, objectName, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("s3 delete failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignedURL menghasilkan presigned GET URL (maksimal 7 hari sesuai batas SigV4)
+func (s *s3Storage) SignedURL(objectName string, expiry time.Duration) (string, error) {
+	canonicalURI, err := s.canonicalURI(objectName)
+	if err != nil {
+		return "", err
+	}
+	if expiry > 7*24*time.Hour {
+		expiry = 7 * 24 * time.Hour
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.accessKey + "/" + s.credentialScope(dateStamp),
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	queryParts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		queryParts = append(queryParts, s3URIEncode(key, true)+"="+s3URIEncode(query[key], true))
+	}
+	canonicalQuery := strings.Join(queryParts, "&")
+
+	canonicalRequest := strings.Join([]string{http.MethodGet, canonicalURI, canonicalQuery, "host:" + s.endpoint + "\n", "host", "UNSIGNED-PAYLOAD"}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, s.credentialScope(dateStamp), s3SHA256Hex([]byte(canonicalRequest))}, "\n")
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Amz-Signature=%s", s.scheme, s.endpoint, canonicalURI, canonicalQuery, s.signature(dateStamp, stringToSign)), nil
+}
@@ -1,13 +1,8 @@
 package helper
 
 import (
-	"bytes"
-	"cloud.google.com/go/storage"
-	"context"
 	"encoding/base64"
 	"fmt"
-	"google.golang.org/api/option"
-	"io"
 	"mime/multipart"
 	"os"
 	"strings"
@@ -23,36 +18,15 @@ func decodeBase64Credential() ([]byte, error) {
 	return credentialsBytes, nil
 }
 
+// UploadImageToGCS mengunggah gambar ke bucket GCS (env GCS_BUCKET) dan mengembalikan URL publiknya.
+// Fitur baru sebaiknya memakai NewFileStorage agar tidak terikat pada GCS.
 func UploadImageToGCS(imageData []byte, imageName string) (string, error) {
-	ctx := context.Background()
-
-	credentialsBytes, err := decodeBase64Credential()
-	if err != nil {
-		return "", err
-	}
-
-	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(credentialsBytes))
-	if err != nil {
-		return "", err
-	}
-
-	bucketName := "destimate"
-
-	object := client.Bucket(bucketName).Object(imageName)
-	wc := object.NewWriter(ctx)
-
-	wc.ContentType = "image/jpeg"
-
-	if _, err := io.Copy(wc, bytes.NewReader(imageData)); err != nil {
-		wc.Close()
-		return "", err
-	}
-
-	if err := wc.Close(); err != nil {
+	gcs := newGCSStorage()
+	if err := gcs.Upload(imageName, imageData, DetectContentType(imageData, imageName)); err != nil {
 		return "", err
 	}
 
-	imageURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, imageName)
+	imageURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s", gcs.bucketName, imageName)
 	return imageURL, nil
 }
 
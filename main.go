@@ -28,13 +28,61 @@ func main() {
 	c := cron.New(cron.WithLocation(loc))
 
 	// Add cron jobs
-	_, err = c.AddFunc("59 23 * * 1-5", func() {
+	// Dijalankan setiap hari, hari istirahat ditentukan oleh shift masing-masing karyawan
+	_, err = c.AddFunc("59 23 * * *", func() {
 		controllers.MarkAbsentEmployees(db)
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	_, err = c.AddFunc("*/15 * * * *", func() {
+		controllers.HandleMissingCheckouts(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Setiap Senin pagi, tandai karyawan yang melewati ambang keterlambatan/absensi
+	_, err = c.AddFunc("0 7 * * 1", func() {
+		controllers.FlagAttendanceIssues(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Terapkan mutasi, promosi dan perubahan gaji terjadwal setiap awal hari
+	_, err = c.AddFunc("5 0 * * *", func() {
+		controllers.ApplyScheduledEmploymentChanges(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Pengingat kontrak/probation yang akan berakhir dalam 30, 14 dan 7 hari
+	_, err = c.AddFunc("0 8 * * *", func() {
+		controllers.SendContractExpiryReminders(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Pengingat dokumen karyawan (izin kerja, sertifikasi, dll) yang akan kedaluwarsa
+	_, err = c.AddFunc("15 8 * * *", func() {
+		controllers.SendDocumentExpiryReminders(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Eskalasi tiket helpdesk yang melewati batas SLA respons pertama atau penyelesaian
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		controllers.EscalateHelpdeskTickets(db)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	_, err = c.AddFunc("0 0 25 * *", func() {
 		controllers.ResetPaidStatus(db)
 	})
@@ -15,3 +15,12 @@ type Announcement struct {
 	CreatedAt      *time.Time `json:"created_at"`
 	UpdatedAt      time.Time
 }
+
+// AnnouncementRead mencatat kapan karyawan membaca dan mengonfirmasi (acknowledge) sebuah pengumuman
+type AnnouncementRead struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AnnouncementID uint       `gorm:"uniqueIndex:idx_announcement_read_employee" json:"announcement_id"`
+	EmployeeID     uint       `gorm:"uniqueIndex:idx_announcement_read_employee" json:"employee_id"`
+	ReadAt         *time.Time `json:"read_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+}
@@ -17,6 +17,8 @@ type Attendance struct {
 	LateMinutes         int        `json:"late_minutes"` // New field for late time in minutes
 	EarlyLeaving        string     `json:"early_leaving"`
 	EarlyLeavingMinutes int        `json:"early_leaving_minutes"` // New field for early leaving time in minutes
+	IsAutoCheckout      bool       `json:"is_auto_checkout"`      // Checkout diisi otomatis oleh sistem
+	KioskID             uint       `json:"kiosk_id"`              // Kiosk yang dipakai saat check-in, 0 jika dari aplikasi
 	CreatedAt           *time.Time `json:"created_at"`
 }
 
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type AttendanceCorrection struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint       `json:"employee_id"`
+	Username         string     `json:"username"`
+	FullNameEmployee string     `json:"full_name_employee"`
+	AttendanceID     uint       `json:"attendance_id"`   // 0 jika belum ada absensi pada tanggal tersebut
+	AttendanceDate   string     `json:"attendance_date"` // Format: yyyy-mm-dd
+	RequestedInTime  string     `json:"requested_in_time"`
+	RequestedOutTime string     `json:"requested_out_time"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	ReviewerRole     string     `json:"reviewer_role"` // Admin atau Manager
+	ReviewerID       uint       `json:"reviewer_id"`
+	ReviewerName     string     `json:"reviewer_name"`
+	ReviewNote       string     `json:"review_note"`
+	CreatedAt        *time.Time `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// AttendanceAuditLog menyimpan nilai absensi sebelum dan sesudah diubah
+type AttendanceAuditLog struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	AttendanceID    uint       `json:"attendance_id"`
+	EmployeeID      uint       `json:"employee_id"`
+	AttendanceDate  string     `json:"attendance_date"`
+	Source          string     `json:"source"` // misal: Correction
+	SourceID        uint       `json:"source_id"`
+	OldInTime       string     `json:"old_in_time"`
+	OldOutTime      string     `json:"old_out_time"`
+	OldStatus       string     `json:"old_status"`
+	OldLate         string     `json:"old_late"`
+	OldEarlyLeaving string     `json:"old_early_leaving"`
+	OldTotalWork    string     `json:"old_total_work"`
+	NewInTime       string     `json:"new_in_time"`
+	NewOutTime      string     `json:"new_out_time"`
+	NewStatus       string     `json:"new_status"`
+	NewLate         string     `json:"new_late"`
+	NewEarlyLeaving string     `json:"new_early_leaving"`
+	NewTotalWork    string     `json:"new_total_work"`
+	ChangedByRole   string     `json:"changed_by_role"`
+	ChangedByID     uint       `json:"changed_by_id"`
+	ChangedByName   string     `json:"changed_by_name"`
+	CreatedAt       *time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AttendanceFlag dicatat oleh job mingguan ketika karyawan melewati ambang keterlambatan atau absensi
+type AttendanceFlag struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint       `json:"employee_id"`
+	FullNameEmployee string     `json:"full_name_employee"`
+	DepartmentID     uint       `json:"department_id"`
+	DepartmentName   string     `json:"department_name"`
+	FlagType         string     `json:"flag_type"` // Chronic Lateness atau Bradford Factor
+	Value            float64    `json:"value"`
+	Threshold        float64    `json:"threshold"`
+	PeriodStart      string     `json:"period_start"` // Format: yyyy-mm-dd
+	PeriodEnd        string     `json:"period_end"`   // Format: yyyy-mm-dd
+	CreatedAt        *time.Time `json:"created_at"`
+}
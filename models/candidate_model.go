@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// RecruitmentStage adalah tahapan pipeline rekrutmen yang dapat diatur admin
+type RecruitmentStage struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Name         string     `json:"name"`
+	StageType    string     `json:"stage_type"` // Screening, Interview, Offer, Hired atau Rejected
+	SortOrder    int        `json:"sort_order"`
+	EmailMessage string     `json:"email_message"` // Pesan email ke kandidat saat masuk tahap ini, kosong berarti pesan default
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type Candidate struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	NewJobID      uint       `json:"new_job_id"`
+	JobTitle      string     `json:"job_title"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Email         string     `json:"email"`
+	ContactNumber string     `json:"contact_number"`
+	CoverLetter   string     `json:"cover_letter"`
+	Source        string     `json:"source"` // Careers page, referral, dll
+	CVFileName    string     `json:"cv_file_name"`
+	CVKey         string     `json:"-"` // Nama objek CV pada storage
+	CVContentType string     `json:"cv_content_type"`
+	StageID       uint       `json:"stage_id"`
+	StageName     string     `json:"stage_name"`
+	StageType     string     `json:"stage_type"`
+	Status        string     `json:"status"` // Active, Hired atau Rejected
+	OfferedSalary float64    `json:"offered_salary"`
+	JoinDate      string     `json:"join_date"`   // Format: yyyy-mm-dd
+	EmployeeID    uint       `json:"employee_id"` // Terisi setelah kandidat direkrut menjadi karyawan
+	AppliedAt     *time.Time `json:"applied_at"`
+	HiredAt       *time.Time `json:"hired_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CandidateStageHistory mencatat setiap perpindahan tahap kandidat beserta catatan admin
+type CandidateStageHistory struct {
+	ID                     uint       `gorm:"primaryKey" json:"id"`
+	CandidateID            uint       `json:"candidate_id"`
+	FromStageID            uint       `json:"from_stage_id"`
+	FromStageName          string     `json:"from_stage_name"`
+	ToStageID              uint       `json:"to_stage_id"`
+	ToStageName            string     `json:"to_stage_name"`
+	Note                   string     `json:"note"`
+	ChangedByAdminID       uint       `json:"changed_by_admin_id"`
+	ChangedByAdminUsername string     `json:"changed_by_admin_username"`
+	CreatedAt              *time.Time `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// EmploymentContract menyimpan kontrak kerja karyawan (PKWT, PKWTT, Probation atau Intern)
+type EmploymentContract struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID         uint       `json:"employee_id"`
+	FullNameEmployee   string     `json:"full_name_employee"`
+	DepartmentID       uint       `json:"department_id"`
+	ContractNumber     string     `json:"contract_number"`
+	ContractType       string     `json:"contract_type"` // PKWT, PKWTT, Probation atau Intern
+	StartDate          string     `json:"start_date"`    // Format: yyyy-mm-dd
+	EndDate            string     `json:"end_date"`      // Kosong untuk PKWTT
+	RenewalCount       int        `json:"renewal_count"`
+	PreviousContractID uint       `json:"previous_contract_id"`
+	Status             string     `json:"status"` // Active, Renewed, Expired atau Terminated
+	DocumentKey        string     `json:"-"`      // Nama objek pada storage, URL download dibuat saat diminta
+	DocumentName       string     `json:"document_name"`
+	Notes              string     `json:"notes"`
+	LastReminderDays   int        `json:"last_reminder_days"` // Pengingat terakhir yang sudah dikirim (30, 14 atau 7)
+	CreatedByAdminID   uint       `json:"created_by_admin_id"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// DocumentType adalah jenis dokumen karyawan, misalnya KTP, NPWP, Ijazah, Sertifikasi atau Izin Kerja
+type DocumentType struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	HasExpiry   bool       `json:"has_expiry" gorm:"default:false"` // Wajib mengisi tanggal kedaluwarsa
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type EmployeeDocument struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint       `json:"employee_id"`
+	FullNameEmployee string     `json:"full_name_employee"`
+	DocumentTypeID   uint       `json:"document_type_id"`
+	DocumentType     string     `json:"document_type"`
+	Title            string     `json:"title"`
+	DocumentNumber   string     `json:"document_number"`
+	IssueDate        string     `json:"issue_date"`  // Format: yyyy-mm-dd
+	ExpiryDate       string     `json:"expiry_date"` // Kosong jika tidak kedaluwarsa
+	FileName         string     `json:"file_name"`
+	StorageKey       string     `json:"-"` // Nama objek pada storage, diakses lewat endpoint download
+	ContentType      string     `json:"content_type"`
+	FileSize         int64      `json:"file_size"`
+	UploadedByRole   string     `json:"uploaded_by_role"` // Employee atau Admin
+	UploadedByID     uint       `json:"uploaded_by_id"`
+	LastReminderDays int        `json:"last_reminder_days"`
+	CreatedAt        *time.Time `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
@@ -14,6 +14,8 @@ type Employee struct {
 	Email         string  `json:"email"`
 	Username      string  `json:"username"`
 	Password      string  `json:"password"`
+	AttendancePIN string  `json:"-"`             // Hash bcrypt PIN untuk check-in di kiosk
+	BiometricPIN  string  `json:"biometric_pin"` // PIN karyawan pada mesin sidik jari
 	ShiftID       uint    `json:"shift_id"`
 	Shift         string  `json:"shift"`
 	RoleID        uint    `json:"role_id"`
@@ -50,6 +52,10 @@ type Employee struct {
 	//Bio Employee
 	Bio string `json:"bio"`
 
+	// Profile Photo Employee (URL endpoint /avatars, file disimpan melalui storage)
+	AvatarURL       string `json:"avatar_url"`
+	ProfilePhotoURL string `json:"profile_photo_url"`
+
 	// Social Profile Employee
 	FacebookURL  string `json:"facebook_url"`
 	InstagramURL string `json:"instagram_url"`
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// EmploymentHistory mencatat perubahan departemen, jabatan, role, shift dan gaji karyawan berdasarkan tanggal efektif
+type EmploymentHistory struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID          uint       `json:"employee_id"`
+	FullNameEmployee    string     `json:"full_name_employee"`
+	ChangeType          string     `json:"change_type"` // Department, Designation, Role, Shift atau Salary
+	OldValueID          uint       `json:"old_value_id"`
+	OldValue            string     `json:"old_value"`
+	NewValueID          uint       `json:"new_value_id"`
+	NewValue            string     `json:"new_value"`
+	OldSalary           float64    `json:"old_salary"`
+	NewSalary           float64    `json:"new_salary"`
+	EffectiveDate       string     `json:"effective_date"` // Format: yyyy-mm-dd
+	Reason              string     `json:"reason"`
+	Status              string     `json:"status"` // Scheduled, Applied atau Cancelled
+	ApprovedByAdminID   uint       `json:"approved_by_admin_id"`
+	ApprovedByAdminName string     `json:"approved_by_admin_name"`
+	AppliedAt           *time.Time `json:"applied_at"`
+	CreatedAt           *time.Time `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
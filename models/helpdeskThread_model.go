@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// HelpdeskComment adalah balasan pada tiket helpdesk, baik dari karyawan maupun admin
+type HelpdeskComment struct {
+	ID          uint                 `gorm:"primaryKey" json:"id"`
+	HelpdeskID  uint                 `json:"helpdesk_id"`
+	AuthorRole  string               `json:"author_role"` // Employee atau Admin
+	AuthorID    uint                 `json:"author_id"`
+	AuthorName  string               `json:"author_name"`
+	AvatarURL   string               `json:"avatar_url"`
+	Message     string               `json:"message"`
+	IsInternal  bool                 `json:"is_internal" gorm:"default:false"` // Catatan internal hanya terlihat oleh admin
+	Attachments []HelpdeskAttachment `gorm:"foreignKey:CommentID;references:ID" json:"attachments"`
+	CreatedAt   *time.Time           `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type HelpdeskAttachment struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	HelpdeskID  uint       `json:"helpdesk_id"`
+	CommentID   uint       `json:"comment_id"`
+	FileName    string     `json:"file_name"`
+	StorageKey  string     `json:"-"`
+	ContentType string     `json:"content_type"`
+	FileSize    int64      `json:"file_size"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+// HelpdeskStatusHistory mencatat setiap perubahan status tiket helpdesk
+type HelpdeskStatusHistory struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	HelpdeskID    uint       `json:"helpdesk_id"`
+	OldStatus     string     `json:"old_status"`
+	NewStatus     string     `json:"new_status"`
+	ChangedByRole string     `json:"changed_by_role"` // Employee atau Admin
+	ChangedByID   uint       `json:"changed_by_id"`
+	ChangedByName string     `json:"changed_by_name"`
+	CreatedAt     *time.Time `json:"created_at"`
+}
@@ -17,4 +17,40 @@ type Helpdesk struct {
 	Status           string     `json:"status"`
 	CreatedAt        *time.Time `json:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Penugasan dan SLA tiket
+	AssignedAdminID       uint       `json:"assigned_admin_id"`
+	AssignedAdminName     string     `json:"assigned_admin_name"`
+	AssignedAt            *time.Time `json:"assigned_at"`
+	FirstResponseDueAt    *time.Time `json:"first_response_due_at"`
+	ResolutionDueAt       *time.Time `json:"resolution_due_at"`
+	FirstRespondedAt      *time.Time `json:"first_responded_at"`
+	ResolvedAt            *time.Time `json:"resolved_at"`
+	FirstResponseBreached bool       `json:"first_response_breached" gorm:"default:false"`
+	ResolutionBreached    bool       `json:"resolution_breached" gorm:"default:false"`
+	EscalationLevel       int        `json:"escalation_level" gorm:"default:0"`
+	EscalatedAt           *time.Time `json:"escalated_at"`
+}
+
+// HelpdeskSLAPolicy menentukan target waktu respons pertama dan penyelesaian per prioritas tiket
+type HelpdeskSLAPolicy struct {
+	ID                   uint       `gorm:"primaryKey" json:"id"`
+	Priority             string     `gorm:"uniqueIndex" json:"priority"` // Low, Medium, High atau Critical
+	FirstResponseMinutes int        `json:"first_response_minutes"`
+	ResolutionMinutes    int        `json:"resolution_minutes"`
+	CreatedAt            *time.Time `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// HelpdeskAgent adalah admin yang menangani tiket helpdesk, DepartmentID 0 berarti menangani semua departemen
+type HelpdeskAgent struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AdminID        uint       `json:"admin_id"`
+	AdminName      string     `json:"admin_name"`
+	DepartmentID   uint       `json:"department_id"`
+	DepartmentName string     `json:"department_name"`
+	IsActive       bool       `json:"is_active" gorm:"default:true"`
+	LastAssignedAt *time.Time `json:"last_assigned_at"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
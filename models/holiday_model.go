@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Holiday struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	HolidayName string     `json:"holiday_name"`
+	HolidayDate string     `json:"holiday_date"` // Format: yyyy-mm-dd
+	Description string     `json:"description"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Interview adalah jadwal wawancara kandidat dengan satu atau lebih karyawan sebagai pewawancara
+type Interview struct {
+	ID                     uint                   `gorm:"primaryKey" json:"id"`
+	CandidateID            uint                   `json:"candidate_id"`
+	CandidateName          string                 `json:"candidate_name"`
+	CandidateEmail         string                 `json:"candidate_email"`
+	NewJobID               uint                   `json:"new_job_id"`
+	JobTitle               string                 `json:"job_title"`
+	DesignationID          uint                   `json:"designation_id"`
+	DesignationName        string                 `json:"designation_name"`
+	Title                  string                 `json:"title"`
+	ScheduledAt            time.Time              `json:"scheduled_at"`
+	DurationMinutes        int                    `json:"duration_minutes"`
+	Location               string                 `json:"location"`
+	MeetingLink            string                 `json:"meeting_link"`
+	Notes                  string                 `json:"notes"`
+	Status                 string                 `json:"status"`   // Scheduled, Completed atau Cancelled
+	Sequence               int                    `json:"sequence"` // SEQUENCE pada file .ics, naik setiap kali jadwal berubah
+	CreatedByAdminID       uint                   `json:"created_by_admin_id"`
+	CreatedByAdminUsername string                 `json:"created_by_admin_username"`
+	Interviewers           []InterviewInterviewer `gorm:"foreignKey:InterviewID" json:"interviewers"`
+	CreatedAt              *time.Time             `json:"created_at"`
+	UpdatedAt              time.Time              `json:"updated_at"`
+}
+
+type InterviewInterviewer struct {
+	ID                uint   `gorm:"primaryKey" json:"id"`
+	InterviewID       uint   `json:"interview_id"`
+	EmployeeID        uint   `json:"employee_id"`
+	FullName          string `json:"full_name"`
+	Email             string `json:"email"`
+	Designation       string `json:"designation"`
+	FeedbackSubmitted bool   `json:"feedback_submitted"`
+}
+
+// InterviewScorecard adalah penilaian terstruktur dari pewawancara, skala 1-5 per kriteria
+type InterviewScorecard struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	InterviewID         uint       `gorm:"uniqueIndex:idx_interview_scorecard" json:"interview_id"`
+	EmployeeID          uint       `gorm:"uniqueIndex:idx_interview_scorecard" json:"employee_id"`
+	CandidateID         uint       `json:"candidate_id"`
+	FullName            string     `json:"full_name"`
+	TechnicalSkills     int        `json:"technical_skills"`
+	Communication       int        `json:"communication"`
+	ProblemSolving      int        `json:"problem_solving"`
+	CultureFit          int        `json:"culture_fit"`
+	ExperienceRelevance int        `json:"experience_relevance"`
+	OverallRating       float64    `json:"overall_rating"`
+	Recommendation      string     `json:"recommendation"` // Strong Hire, Hire, No Hire atau Strong No Hire
+	Strengths           string     `json:"strengths"`
+	Concerns            string     `json:"concerns"`
+	Comments            string     `json:"comments"`
+	SubmittedAt         *time.Time `json:"submitted_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
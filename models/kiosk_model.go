@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type Kiosk struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	KioskName    string     `json:"kiosk_name"`
+	Location     string     `json:"location"`
+	APIKeyHash   string     `json:"-"`
+	APIKeyPrefix string     `json:"api_key_prefix"` // Beberapa karakter awal API key untuk identifikasi
+	IsActive     bool       `json:"is_active" gorm:"default:true"`
+	LastSeenAt   *time.Time `json:"last_seen_at"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// KioskPINAttempt mencatat percobaan PIN gagal per kiosk dan username untuk mencegah tebakan PIN
+type KioskPINAttempt struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	KioskID        uint       `gorm:"uniqueIndex:idx_kiosk_pin_attempt" json:"kiosk_id"`
+	Username       string     `gorm:"uniqueIndex:idx_kiosk_pin_attempt" json:"username"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LastFailedAt   *time.Time `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type KnowledgeBaseCategory struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// KnowledgeBaseArticle adalah artikel bantuan, kolom search_vector (tsvector) dibuat oleh migrasi di config
+type KnowledgeBaseArticle struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	CategoryID      uint       `json:"category_id"`
+	CategoryName    string     `json:"category_name"`
+	Title           string     `json:"title"`
+	Content         string     `json:"content"`
+	Tags            string     `json:"tags"` // Dipisahkan koma, misalnya: cuti, payslip, bpjs
+	IsPublished     bool       `json:"is_published" gorm:"default:false"`
+	ViewCount       int        `json:"view_count" gorm:"default:0"`
+	HelpfulCount    int        `json:"helpful_count" gorm:"default:0"`
+	NotHelpfulCount int        `json:"not_helpful_count" gorm:"default:0"`
+	AuthorName      string     `json:"author_name"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// KnowledgeBaseSuggestion mencatat artikel yang disarankan saat karyawan menyusun tiket helpdesk,
+// dipakai untuk menghitung deflection (tiket yang tidak jadi dibuat karena terjawab oleh artikel)
+type KnowledgeBaseSuggestion struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID     uint       `json:"employee_id"`
+	DraftSubject   string     `json:"draft_subject"`
+	ArticleIDs     string     `json:"article_ids"` // Dipisahkan koma
+	SuggestedCount int        `json:"suggested_count"`
+	Deflected      bool       `json:"deflected" gorm:"default:false"`
+	HelpdeskID     uint       `json:"helpdesk_id"` // Terisi jika karyawan tetap membuat tiket
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// OffboardingChecklist dibuat saat karyawan mengajukan resign atau saat proses exit karyawan dicatat
+type OffboardingChecklist struct {
+	ID                uint              `gorm:"primaryKey" json:"id"`
+	EmployeeID        uint              `json:"employee_id"`
+	FullName          string            `json:"full_name"`
+	ExitEmployeeID    uint              `json:"exit_employee_id"` // 0 jika proses exit belum dicatat
+	TerminationReason string            `json:"termination_reason"`
+	LastWorkingDate   string            `json:"last_working_date"` // Format: yyyy-mm-dd
+	ManagerID         uint              `json:"manager_id"`
+	ManagerName       string            `json:"manager_name"`
+	Status            string            `json:"status"` // In Progress atau Completed
+	Tasks             []OffboardingTask `gorm:"foreignKey:ChecklistID" json:"tasks"`
+	CompletedAt       *time.Time        `json:"completed_at"`
+	CreatedAt         *time.Time        `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// OffboardingTask mengikuti pola OnboardingTask; tugas HR dikerjakan admin, tugas IT dan Manager oleh karyawan yang ditunjuk
+type OffboardingTask struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	ChecklistID        uint       `json:"checklist_id"`
+	EmployeeID         uint       `json:"employee_id"` // Karyawan yang sedang offboarding
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Category           string     `json:"category"` // Asset Return, Knowledge Handover, Account Deactivation, Exit Interview atau Final Settlement
+	AssigneeRole       string     `json:"assignee_role"`
+	AssigneeEmployeeID uint       `json:"assignee_employee_id"` // 0 berarti dikerjakan oleh HR admin
+	AssigneeName       string     `json:"assignee_name"`
+	DueDate            string     `json:"due_date"`
+	SortOrder          int        `json:"sort_order"`
+	Status             string     `json:"status"` // Pending atau Completed
+	Note               string     `json:"note"`
+	CompletedByRole    string     `json:"completed_by_role"` // Admin, Employee atau System
+	CompletedByID      uint       `json:"completed_by_id"`
+	CompletedByName    string     `json:"completed_by_name"`
+	CompletedAt        *time.Time `json:"completed_at"`
+}
+
+// FinalSettlement menyimpan rincian perhitungan pesangon dan hak akhir karyawan (PP 35/2021)
+type FinalSettlement struct {
+	ID                uint   `gorm:"primaryKey" json:"id"`
+	ChecklistID       uint   `gorm:"uniqueIndex" json:"checklist_id"`
+	EmployeeID        uint   `json:"employee_id"`
+	FullName          string `json:"full_name"`
+	TerminationReason string `json:"termination_reason"`
+	JoinDate          string `json:"join_date"`         // Format: yyyy-mm-dd
+	LastWorkingDate   string `json:"last_working_date"` // Format: yyyy-mm-dd
+	ServiceYears      int    `json:"service_years"`
+	ServiceMonths     int    `json:"service_months"` // Sisa bulan di luar tahun penuh
+
+	// Gaji bulan terakhir
+	MonthlySalary     float64 `json:"monthly_salary"`   // Gaji pokok bulan terakhir
+	FixedAllowances   float64 `json:"fixed_allowances"` // Tunjangan tetap, ikut menjadi dasar upah pesangon
+	DaysWorked        int     `json:"days_worked"`
+	DaysInMonth       int     `json:"days_in_month"`
+	SalaryAlreadyPaid bool    `json:"salary_already_paid"`
+	ProratedSalary    float64 `json:"prorated_salary"`
+
+	// Penggantian cuti tahunan yang belum diambil
+	UnusedLeaveDays float64 `json:"unused_leave_days"`
+	DailyWage       float64 `json:"daily_wage"`
+	LeaveEncashment float64 `json:"leave_encashment"`
+
+	// Pesangon (UP), penghargaan masa kerja (UPMK) dan kompensasi PKWT
+	SeveranceMultiplier  float64 `json:"severance_multiplier"`
+	SeveranceMonths      float64 `json:"severance_months"`
+	SeverancePay         float64 `json:"severance_pay"`
+	ServiceAwardMonths   float64 `json:"service_award_months"`
+	ServiceAwardPay      float64 `json:"service_award_pay"`
+	ContractCompensation float64 `json:"contract_compensation"`
+	OtherEarnings        float64 `json:"other_earnings"`
+
+	// Potongan
+	LoanRecovery    float64 `json:"loan_recovery"`
+	AdvanceRecovery float64 `json:"advance_recovery"`
+	OtherDeductions float64 `json:"other_deductions"`
+
+	GrossAmount     float64    `json:"gross_amount"`
+	TotalDeductions float64    `json:"total_deductions"`
+	NetAmount       float64    `json:"net_amount"`
+	Notes           string     `json:"notes"`
+	Status          string     `json:"status"` // Draft atau Finalized
+	CalculatedByID  uint       `json:"calculated_by_id"`
+	FinalizedByID   uint       `json:"finalized_by_id"`
+	FinalizedAt     *time.Time `json:"finalized_at"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// LetterTemplate adalah template surat penawaran atau kontrak kerja dengan placeholder {{...}}
+type LetterTemplate struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Name         string     `json:"name"`
+	TemplateType string     `json:"template_type"` // Offer Letter atau Employment Contract
+	Subject      string     `json:"subject"`
+	Body         string     `json:"body"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// OfferLetter adalah surat yang sudah dirender dari template untuk kandidat atau karyawan
+type OfferLetter struct {
+	ID                     uint                   `gorm:"primaryKey" json:"id"`
+	TemplateID             uint                   `json:"template_id"`
+	TemplateName           string                 `json:"template_name"`
+	LetterType             string                 `json:"letter_type"` // Offer Letter atau Employment Contract
+	LetterNumber           string                 `json:"letter_number"`
+	CandidateID            uint                   `json:"candidate_id"`
+	EmployeeID             uint                   `json:"employee_id"`
+	RecipientName          string                 `json:"recipient_name"`
+	RecipientEmail         string                 `json:"recipient_email"`
+	JobTitle               string                 `json:"job_title"`
+	DesignationName        string                 `json:"designation_name"`
+	DepartmentName         string                 `json:"department_name"`
+	BasicSalary            float64                `json:"basic_salary"`
+	TotalAllowances        float64                `json:"total_allowances"`
+	Allowances             []OfferLetterAllowance `gorm:"foreignKey:OfferLetterID" json:"allowances"`
+	StartDate              string                 `json:"start_date"`  // Format: yyyy-mm-dd
+	ExpiryDate             string                 `json:"expiry_date"` // Batas waktu respon, format: yyyy-mm-dd
+	Subject                string                 `json:"subject"`
+	Content                string                 `json:"content"`
+	DocumentName           string                 `json:"document_name"`
+	DocumentKey            string                 `json:"-"`
+	ResponseToken          string                 `json:"-"`      // Token tautan respon pada email
+	Status                 string                 `json:"status"` // Draft, Sent, Accepted, Declined, Expired atau Withdrawn
+	SentAt                 *time.Time             `json:"sent_at"`
+	RespondedAt            *time.Time             `json:"responded_at"`
+	AcknowledgedAt         *time.Time             `json:"acknowledged_at"`   // Waktu persetujuan elektronik
+	AcknowledgedName       string                 `json:"acknowledged_name"` // Nama yang diketik sebagai tanda tangan elektronik
+	AcknowledgedIP         string                 `json:"acknowledged_ip"`
+	AcknowledgedUserAgent  string                 `json:"acknowledged_user_agent"`
+	DeclineReason          string                 `json:"decline_reason"`
+	CreatedByAdminID       uint                   `json:"created_by_admin_id"`
+	CreatedByAdminUsername string                 `json:"created_by_admin_username"`
+	CreatedAt              *time.Time             `json:"created_at"`
+	UpdatedAt              time.Time              `json:"updated_at"`
+}
+
+type OfferLetterAllowance struct {
+	ID            uint    `gorm:"primaryKey" json:"id"`
+	OfferLetterID uint    `json:"offer_letter_id"`
+	Name          string  `json:"name"`
+	Amount        float64 `json:"amount"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// OnboardingTemplate adalah daftar tugas standar yang disalin menjadi checklist saat karyawan baru direkrut
+type OnboardingTemplate struct {
+	ID          uint                     `gorm:"primaryKey" json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	IsDefault   bool                     `json:"is_default"`
+	Tasks       []OnboardingTemplateTask `gorm:"foreignKey:TemplateID" json:"tasks"`
+	CreatedAt   *time.Time               `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+type OnboardingTemplateTask struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	TemplateID   uint   `json:"template_id"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Category     string `json:"category"`      // IT Account, Contract, BPJS, Bank Details, Policy atau Orientation
+	AssigneeRole string `json:"assignee_role"` // HR, IT atau Manager
+	DueDays      int    `json:"due_days"`      // Jumlah hari dari tanggal bergabung, boleh negatif
+	SortOrder    int    `json:"sort_order"`
+}
+
+type OnboardingChecklist struct {
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	EmployeeID   uint             `json:"employee_id"`
+	FullName     string           `json:"full_name"`
+	CandidateID  uint             `json:"candidate_id"`
+	TemplateID   uint             `json:"template_id"`
+	TemplateName string           `json:"template_name"`
+	JoinDate     string           `json:"join_date"` // Format: yyyy-mm-dd
+	ManagerID    uint             `json:"manager_id"`
+	ManagerName  string           `json:"manager_name"`
+	Status       string           `json:"status"` // In Progress atau Completed
+	Tasks        []OnboardingTask `gorm:"foreignKey:ChecklistID" json:"tasks"`
+	CompletedAt  *time.Time       `json:"completed_at"`
+	CreatedAt    *time.Time       `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// OnboardingTask adalah tugas pada checklist; tugas HR dikerjakan admin, tugas IT dan Manager oleh karyawan yang ditunjuk
+type OnboardingTask struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	ChecklistID        uint       `json:"checklist_id"`
+	EmployeeID         uint       `json:"employee_id"` // Karyawan baru yang sedang onboarding
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Category           string     `json:"category"`
+	AssigneeRole       string     `json:"assignee_role"`
+	AssigneeEmployeeID uint       `json:"assignee_employee_id"` // 0 berarti dikerjakan oleh HR admin
+	AssigneeName       string     `json:"assignee_name"`
+	DueDate            string     `json:"due_date"`
+	SortOrder          int        `json:"sort_order"`
+	Status             string     `json:"status"` // Pending atau Completed
+	Note               string     `json:"note"`
+	CompletedByRole    string     `json:"completed_by_role"` // Admin, Employee atau System
+	CompletedByID      uint       `json:"completed_by_id"`
+	CompletedByName    string     `json:"completed_by_name"`
+	CompletedAt        *time.Time `json:"completed_at"`
+}
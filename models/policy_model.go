@@ -6,7 +6,33 @@ type Policy struct {
 	ID                     uint       `gorm:"primaryKey" json:"id"`
 	Title                  string     `json:"title"`
 	Description            string     `json:"description"`
+	LatestVersion          int        `json:"latest_version" gorm:"default:0"`
 	CreatedByAdminID       uint       `json:"created_by_admin_id"`
 	CreatedByAdminUsername string     `json:"created_by_admin_username"`
 	CreatedAt              *time.Time `json:"created_at"`
 }
+
+// PolicyVersion adalah isi kebijakan yang berlaku mulai EffectiveDate, versi terbaru yang sudah efektif wajib di-acknowledge karyawan
+type PolicyVersion struct {
+	ID                       uint       `gorm:"primaryKey" json:"id"`
+	PolicyID                 uint       `gorm:"uniqueIndex:idx_policy_version_number" json:"policy_id"`
+	VersionNumber            int        `gorm:"uniqueIndex:idx_policy_version_number" json:"version_number"`
+	Content                  string     `json:"content"`
+	ChangeSummary            string     `json:"change_summary"`
+	EffectiveDate            string     `json:"effective_date"` // Format: yyyy-mm-dd
+	DocumentName             string     `json:"document_name"`
+	DocumentKey              string     `json:"-"` // Nama objek PDF pada storage
+	PublishedByAdminID       uint       `json:"published_by_admin_id"`
+	PublishedByAdminUsername string     `json:"published_by_admin_username"`
+	CreatedAt                *time.Time `json:"created_at"`
+}
+
+type PolicyAcknowledgement struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	PolicyID        uint       `json:"policy_id"`
+	PolicyVersionID uint       `gorm:"uniqueIndex:idx_policy_ack_version_employee" json:"policy_version_id"`
+	VersionNumber   int        `json:"version_number"`
+	EmployeeID      uint       `gorm:"uniqueIndex:idx_policy_ack_version_employee" json:"employee_id"`
+	IPAddress       string     `json:"ip_address"`
+	AcknowledgedAt  *time.Time `json:"acknowledged_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type ShiftRoster struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	EmployeeID       uint       `gorm:"uniqueIndex:idx_shift_roster_employee_date" json:"employee_id"`
+	FullNameEmployee string     `json:"full_name_employee"`
+	DepartmentID     uint       `json:"department_id"`
+	ShiftID          uint       `json:"shift_id"`
+	ShiftName        string     `json:"shift_name"`
+	RosterDate       string     `gorm:"uniqueIndex:idx_shift_roster_employee_date" json:"roster_date"` // Format: yyyy-mm-dd
+	Remarks          string     `json:"remarks"`
+	CreatedAt        *time.Time `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type ShiftSwapRequest struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	RequesterID         uint       `json:"requester_id"`
+	RequesterFullName   string     `json:"requester_full_name"`
+	RequesterShiftID    uint       `json:"requester_shift_id"`
+	RequesterShiftName  string     `json:"requester_shift_name"`
+	TargetEmployeeID    uint       `json:"target_employee_id"`
+	TargetFullName      string     `json:"target_full_name"`
+	TargetShiftID       uint       `json:"target_shift_id"`
+	TargetShiftName     string     `json:"target_shift_name"`
+	SwapDate            string     `json:"swap_date"` // Format: yyyy-mm-dd
+	Reason              string     `json:"reason"`
+	Status              string     `json:"status"`
+	ReviewedByAdminID   uint       `json:"reviewed_by_admin_id"`
+	ReviewedByAdminName string     `json:"reviewed_by_admin_name"`
+	CreatedAt           *time.Time `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
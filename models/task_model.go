@@ -23,9 +23,11 @@ type Task struct {
 }
 
 type Note struct {
-	ID        uint       `gorm:"primaryKey" json:"id"`
-	TaskID    uint       `json:"task_id"`
-	NoteText  string     `json:"note_text"`
-	Fullname  string     `json:"fullname"`
-	CreatedAt *time.Time `json:"created_at"`
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	TaskID     uint       `json:"task_id"`
+	NoteText   string     `json:"note_text"`
+	Fullname   string     `json:"fullname"`
+	EmployeeID uint       `json:"employee_id"`
+	AvatarURL  string     `json:"avatar_url"`
+	CreatedAt  *time.Time `json:"created_at"`
 }
@@ -25,6 +25,16 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.POST("/admin/signup", controllers.RegisterAdminHR(db, secretKey))
 	e.POST("/admin/signin", controllers.SignInAdmin(db, secretKey))
 	e.GET("/verify", controllers.VerifyEmail(db))
+	e.GET("/files/*", controllers.ServeStorageFile())
+	e.GET("/avatars/:id/:size", controllers.GetEmployeeAvatar(db))
+
+	//Careers Page
+	e.GET("/careers/jobs", controllers.GetPublishedJobs(db))
+	e.GET("/careers/jobs/:id", controllers.GetPublishedJobByID(db))
+	e.POST("/careers/jobs/:id/apply", controllers.ApplyForJob(db))
+	e.GET("/careers/offers/:token", controllers.GetOfferLetterByToken(db))
+	e.GET("/careers/offers/:token/pdf", controllers.DownloadOfferLetterByToken(db))
+	e.POST("/careers/offers/:token/respond", controllers.RespondOfferLetterByToken(db))
 
 	//Shift Admin
 	e.POST("/shifts", controllers.CreateShiftByAdmin(db, secretKey))
@@ -33,6 +43,19 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/shifts/:id", controllers.GetShiftByIDByAdmin(db, secretKey))
 	e.PUT("/shifts/:id", controllers.EditShiftByIDByAdmin(db, secretKey))
 	e.DELETE("/shifts/:id", controllers.DeleteShiftByIDByAdmin(db, secretKey))
+	e.PUT("/shifts/:id/attendance_rules", controllers.UpdateShiftAttendanceRulesByAdmin(db, secretKey))
+
+	//Shift Roster Admin
+	e.POST("/shift_rosters", controllers.CreateShiftRosterByAdmin(db, secretKey))
+	e.POST("/shift_rosters/bulk", controllers.BuildWeeklyShiftRosterByAdmin(db, secretKey))
+	e.GET("/shift_rosters", controllers.GetAllShiftRostersByAdmin(db, secretKey))
+	e.GET("/shift_rosters/:id", controllers.GetShiftRosterByIDByAdmin(db, secretKey))
+	e.PUT("/shift_rosters/:id", controllers.UpdateShiftRosterByIDByAdmin(db, secretKey))
+	e.DELETE("/shift_rosters/:id", controllers.DeleteShiftRosterByIDByAdmin(db, secretKey))
+
+	//Shift Swap Admin
+	e.GET("/shift_swaps", controllers.GetAllShiftSwapRequestsByAdmin(db, secretKey))
+	e.PUT("/shift_swaps/:id", controllers.UpdateShiftSwapRequestByAdmin(db, secretKey))
 
 	//Role Admin
 	e.POST("/roles", controllers.CreateRoleByAdmin(db, secretKey))
@@ -64,6 +87,11 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/policies/:id", controllers.GetPolicyByIDByAdmin(db, secretKey))
 	e.PUT("/policies/:id", controllers.UpdatePolicyByIDByAdmin(db, secretKey))
 	e.DELETE("/policies/:id", controllers.DeletePolicyByIDByAdmin(db, secretKey))
+	e.GET("/policies/compliance_report", controllers.GetPolicyComplianceReportByAdmin(db, secretKey))
+	e.POST("/policies/:id/versions", controllers.PublishPolicyVersionByAdmin(db, secretKey))
+	e.GET("/policies/:id/versions", controllers.GetPolicyVersionsByAdmin(db, secretKey))
+	e.GET("/policies/:id/versions/:version_id/document", controllers.DownloadPolicyVersionDocumentByAdmin(db, secretKey))
+	e.GET("/policies/:id/outstanding_acknowledgements", controllers.GetOutstandingPolicyAcknowledgementsByAdmin(db, secretKey))
 
 	//Announcement Admin
 	e.POST("/announcements", controllers.CreateAnnouncementByAdmin(db, secretKey))
@@ -71,6 +99,7 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/announcements/:id", controllers.GetAnnouncementByIDForAdmin(db, secretKey))
 	e.PUT("/announcements/:id", controllers.UpdateAnnouncementForAdmin(db, secretKey))
 	e.DELETE("/announcements/:id", controllers.DeleteAnnouncementForAdmin(db, secretKey))
+	e.GET("/announcements/:id/read_report", controllers.GetAnnouncementReadReportByAdmin(db, secretKey))
 
 	//Project Admin
 	e.POST("/projects", controllers.CreateProjectByAdmin(db, secretKey))
@@ -114,6 +143,30 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.PUT("/helpdesks/:id", controllers.UpdateHelpdeskByIDByAdmin(db, secretKey))
 	e.DELETE("/helpdesks/:id", controllers.DeleteHelpdeskByIDByAdmin(db, secretKey))
 	e.GET("/helpdesks/progress-bar", controllers.GetTicketStatsByAdmin(db, secretKey))
+	e.POST("/helpdesks/:id/comments", controllers.CreateHelpdeskCommentByAdmin(db, secretKey))
+	e.GET("/helpdesks/:id/comments", controllers.GetHelpdeskCommentsByAdmin(db, secretKey))
+	e.GET("/helpdesks/:id/status_history", controllers.GetHelpdeskStatusHistoryByAdmin(db, secretKey))
+	e.GET("/helpdesks/:id/attachments/:attachment_id", controllers.DownloadHelpdeskAttachmentByAdmin(db, secretKey))
+	e.PUT("/helpdesks/:id/assign", controllers.AssignHelpdeskByAdmin(db, secretKey))
+
+	//Helpdesk SLA & Agent Admin
+	e.GET("/helpdesk_sla_policies", controllers.GetHelpdeskSLAPoliciesByAdmin(db, secretKey))
+	e.PUT("/helpdesk_sla_policies/:priority", controllers.UpdateHelpdeskSLAPolicyByAdmin(db, secretKey))
+	e.POST("/helpdesk_agents", controllers.CreateHelpdeskAgentByAdmin(db, secretKey))
+	e.GET("/helpdesk_agents", controllers.GetAllHelpdeskAgentsByAdmin(db, secretKey))
+	e.PUT("/helpdesk_agents/:id", controllers.UpdateHelpdeskAgentByAdmin(db, secretKey))
+	e.DELETE("/helpdesk_agents/:id", controllers.DeleteHelpdeskAgentByAdmin(db, secretKey))
+
+	//Knowledge Base Admin
+	e.POST("/knowledge_base/categories", controllers.CreateKnowledgeBaseCategoryByAdmin(db, secretKey))
+	e.GET("/knowledge_base/categories", controllers.GetAllKnowledgeBaseCategories(db, secretKey))
+	e.PUT("/knowledge_base/categories/:id", controllers.UpdateKnowledgeBaseCategoryByAdmin(db, secretKey))
+	e.DELETE("/knowledge_base/categories/:id", controllers.DeleteKnowledgeBaseCategoryByAdmin(db, secretKey))
+	e.POST("/knowledge_base/articles", controllers.CreateKnowledgeBaseArticleByAdmin(db, secretKey))
+	e.GET("/knowledge_base/articles", controllers.GetAllKnowledgeBaseArticlesByAdmin(db, secretKey))
+	e.GET("/knowledge_base/articles/:id", controllers.GetKnowledgeBaseArticleByIDByAdmin(db, secretKey))
+	e.PUT("/knowledge_base/articles/:id", controllers.UpdateKnowledgeBaseArticleByAdmin(db, secretKey))
+	e.DELETE("/knowledge_base/articles/:id", controllers.DeleteKnowledgeBaseArticleByAdmin(db, secretKey))
 
 	//Payroll
 	e.GET("/payrolls", controllers.GetAllEmployeesPayrollInfo(db, secretKey))
@@ -178,6 +231,35 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/attendances/:id", controllers.GetAttendanceByIDByAdmin(db, secretKey))
 	e.PUT("/attendances/:id", controllers.UpdateAttendanceByIDByAdmin(db, secretKey))
 	e.DELETE("/attendances/:id", controllers.DeleteAttendanceByIDByAdmin(db, secretKey))
+	e.POST("/attendances/mark_absent", controllers.MarkAbsentEmployeesByAdmin(db, secretKey))
+	e.GET("/attendances/:id/audit_logs", controllers.GetAttendanceAuditLogsByAdmin(db, secretKey))
+	e.POST("/attendances/biometric_import", controllers.ImportBiometricAttendanceByAdmin(db, secretKey))
+	e.PUT("/admin/employees/:id/biometric_pin", controllers.SetEmployeeBiometricPINByAdmin(db, secretKey))
+
+	//Attendance Correction Admin
+	e.GET("/attendance_corrections", controllers.GetAllAttendanceCorrectionsByAdmin(db, secretKey))
+	e.PUT("/attendance_corrections/:id", controllers.ReviewAttendanceCorrectionByAdmin(db, secretKey))
+
+	//Kiosk Admin
+	e.POST("/kiosks", controllers.CreateKioskByAdmin(db, secretKey))
+	e.GET("/kiosks", controllers.GetAllKiosksByAdmin(db, secretKey))
+	e.GET("/kiosks/:id", controllers.GetKioskByIDByAdmin(db, secretKey))
+	e.PUT("/kiosks/:id", controllers.UpdateKioskByIDByAdmin(db, secretKey))
+	e.POST("/kiosks/:id/regenerate_key", controllers.RegenerateKioskAPIKeyByAdmin(db, secretKey))
+	e.DELETE("/kiosks/:id", controllers.DeleteKioskByIDByAdmin(db, secretKey))
+	e.PUT("/admin/employees/:id/attendance_pin", controllers.SetEmployeeAttendancePINByAdmin(db, secretKey))
+
+	//Kiosk Device
+	e.GET("/kiosk/qr_token", controllers.GetKioskQRToken(db, secretKey))
+	e.POST("/kiosk/checkin", controllers.KioskPINCheckIn(db, secretKey))
+	e.POST("/kiosk/checkout", controllers.KioskPINCheckOut(db, secretKey))
+
+	//Holiday
+	e.POST("/holidays", controllers.CreateHolidayByAdmin(db, secretKey))
+	e.GET("/holidays", controllers.GetAllHolidaysByAdmin(db, secretKey))
+	e.GET("/holidays/:id", controllers.GetHolidayByIDByAdmin(db, secretKey))
+	e.PUT("/holidays/:id", controllers.UpdateHolidayByIDByAdmin(db, secretKey))
+	e.DELETE("/holidays/:id", controllers.DeleteHolidayByIDByAdmin(db, secretKey))
 
 	//Overtime 	Request
 	e.POST("/overtime_requests", controllers.CreateOvertimeRequestByAdmin(db, secretKey))
@@ -253,6 +335,51 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/jobs/:id", controllers.GetNewJobByIDByAdmin(db, secretKey))
 	e.PUT("/jobs/:id", controllers.UpdateNewJobByIDByAdmin(db, secretKey))
 	e.DELETE("/jobs/:id", controllers.DeleteNewJobByIDByAdmin(db, secretKey))
+	e.GET("/recruitment_stages", controllers.GetRecruitmentStagesByAdmin(db, secretKey))
+	e.POST("/recruitment_stages", controllers.CreateRecruitmentStageByAdmin(db, secretKey))
+	e.PUT("/recruitment_stages/:id", controllers.UpdateRecruitmentStageByAdmin(db, secretKey))
+	e.DELETE("/recruitment_stages/:id", controllers.DeleteRecruitmentStageByAdmin(db, secretKey))
+	e.GET("/candidates", controllers.GetAllCandidatesByAdmin(db, secretKey))
+	e.GET("/candidates/:id", controllers.GetCandidateByIDByAdmin(db, secretKey))
+	e.PUT("/candidates/:id/stage", controllers.TransitionCandidateStageByAdmin(db, secretKey))
+	e.GET("/candidates/:id/cv", controllers.DownloadCandidateCVByAdmin(db, secretKey))
+	e.DELETE("/candidates/:id", controllers.DeleteCandidateByAdmin(db, secretKey))
+	e.GET("/candidates/:id/feedback", controllers.GetCandidateFeedbackByAdmin(db, secretKey))
+	e.POST("/interviews", controllers.ScheduleInterviewByAdmin(db, secretKey))
+	e.GET("/interviews", controllers.GetAllInterviewsByAdmin(db, secretKey))
+	e.GET("/interviews/:id", controllers.GetInterviewByIDByAdmin(db, secretKey))
+	e.PUT("/interviews/:id", controllers.UpdateInterviewByAdmin(db, secretKey))
+	e.PUT("/interviews/:id/cancel", controllers.CancelInterviewByAdmin(db, secretKey))
+	e.POST("/candidates/:id/hire", controllers.HireCandidateByAdmin(db, secretKey))
+	e.GET("/letter_templates", controllers.GetLetterTemplatesByAdmin(db, secretKey))
+	e.POST("/letter_templates", controllers.CreateLetterTemplateByAdmin(db, secretKey))
+	e.PUT("/letter_templates/:id", controllers.UpdateLetterTemplateByAdmin(db, secretKey))
+	e.DELETE("/letter_templates/:id", controllers.DeleteLetterTemplateByAdmin(db, secretKey))
+	e.POST("/offer_letters", controllers.CreateOfferLetterByAdmin(db, secretKey))
+	e.GET("/offer_letters", controllers.GetAllOfferLettersByAdmin(db, secretKey))
+	e.GET("/offer_letters/:id", controllers.GetOfferLetterByIDByAdmin(db, secretKey))
+	e.GET("/offer_letters/:id/pdf", controllers.DownloadOfferLetterByAdmin(db, secretKey))
+	e.POST("/offer_letters/:id/send", controllers.SendOfferLetterByAdmin(db, secretKey))
+	e.PUT("/offer_letters/:id/withdraw", controllers.WithdrawOfferLetterByAdmin(db, secretKey))
+	e.DELETE("/offer_letters/:id", controllers.DeleteOfferLetterByAdmin(db, secretKey))
+
+	//Onboarding
+	e.GET("/onboarding_templates", controllers.GetOnboardingTemplatesByAdmin(db, secretKey))
+	e.POST("/onboarding_templates", controllers.CreateOnboardingTemplateByAdmin(db, secretKey))
+	e.PUT("/onboarding_templates/:id", controllers.UpdateOnboardingTemplateByAdmin(db, secretKey))
+	e.DELETE("/onboarding_templates/:id", controllers.DeleteOnboardingTemplateByAdmin(db, secretKey))
+	e.GET("/onboarding_checklists", controllers.GetAllOnboardingChecklistsByAdmin(db, secretKey))
+	e.GET("/onboarding_checklists/:id", controllers.GetOnboardingChecklistByIDByAdmin(db, secretKey))
+	e.PUT("/onboarding_tasks/:id", controllers.UpdateOnboardingTaskByAdmin(db, secretKey))
+
+	//Offboarding
+	e.POST("/offboarding", controllers.CreateOffboardingByAdmin(db, secretKey))
+	e.GET("/offboarding", controllers.GetAllOffboardingChecklistsByAdmin(db, secretKey))
+	e.GET("/offboarding/:id", controllers.GetOffboardingChecklistByIDByAdmin(db, secretKey))
+	e.PUT("/offboarding_tasks/:id", controllers.UpdateOffboardingTaskByAdmin(db, secretKey))
+	e.POST("/offboarding/:id/settlement", controllers.CalculateFinalSettlementByAdmin(db, secretKey))
+	e.PUT("/offboarding/:id/settlement/finalize", controllers.FinalizeFinalSettlementByAdmin(db, secretKey))
+	e.GET("/offboarding/:id/settlement/pdf", controllers.DownloadFinalSettlementPDFByAdmin(db, secretKey))
 
 	//Leave Request Type
 	e.POST("/leave_request_types", controllers.CreateLeaveRequestTypeByAdmin(db, secretKey))
@@ -276,6 +403,32 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/admin/employees/:id", controllers.GetEmployeeByIDByAdmin(db, secretKey))
 	e.PUT("/admin/employees/:id", controllers.UpdateEmployeeAccountByAdmin(db, secretKey))
 	e.DELETE("/admin/employees/:id", controllers.DeleteEmployeeAccountByAdmin(db, secretKey))
+	e.POST("/admin/employees/:id/photo", controllers.UploadEmployeePhotoByAdmin(db, secretKey))
+
+	//Employment History Admin
+	e.POST("/admin/employees/:id/employment_changes", controllers.CreateEmploymentChangeByAdmin(db, secretKey))
+	e.GET("/admin/employees/:id/employment_history", controllers.GetEmploymentTimelineByAdmin(db, secretKey))
+	e.DELETE("/admin/employees/:id/employment_history/:history_id", controllers.CancelEmploymentChangeByAdmin(db, secretKey))
+
+	//Contract Admin
+	e.POST("/contracts", controllers.CreateContractByAdmin(db, secretKey))
+	e.GET("/contracts", controllers.GetAllContractsByAdmin(db, secretKey))
+	e.GET("/contracts/upcoming_expiries", controllers.GetUpcomingContractExpiriesByAdmin(db, secretKey))
+	e.GET("/contracts/:id", controllers.GetContractByIDByAdmin(db, secretKey))
+	e.PUT("/contracts/:id", controllers.UpdateContractByIDByAdmin(db, secretKey))
+	e.DELETE("/contracts/:id", controllers.DeleteContractByIDByAdmin(db, secretKey))
+	e.POST("/contracts/:id/document", controllers.UploadContractDocumentByAdmin(db, secretKey))
+	e.POST("/contracts/:id/renew", controllers.RenewContractByAdmin(db, secretKey))
+
+	//Employee Document Admin
+	e.POST("/document_types", controllers.CreateDocumentTypeByAdmin(db, secretKey))
+	e.GET("/document_types", controllers.GetAllDocumentTypes(db, secretKey))
+	e.PUT("/document_types/:id", controllers.UpdateDocumentTypeByIDByAdmin(db, secretKey))
+	e.DELETE("/document_types/:id", controllers.DeleteDocumentTypeByIDByAdmin(db, secretKey))
+	e.POST("/employee_documents", controllers.UploadEmployeeDocumentByAdmin(db, secretKey))
+	e.GET("/employee_documents", controllers.GetAllEmployeeDocumentsByAdmin(db, secretKey))
+	e.GET("/employee_documents/:id/download", controllers.DownloadEmployeeDocumentByAdmin(db, secretKey))
+	e.DELETE("/employee_documents/:id", controllers.DeleteEmployeeDocumentByAdmin(db, secretKey))
 
 	//Client Admin
 	e.POST("/admin/clients", controllers.CreateClientAccountByAdmin(db, secretKey))
@@ -301,6 +454,8 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.POST("/employee/signin", controllers.EmployeeLogin(db, secretKey))
 	e.GET("/profile", controllers.EmployeeProfile(db, secretKey))
 	e.PUT("/profile/edit", controllers.UpdateEmployeeProfile(db, secretKey))
+	e.POST("/profile/photo", controllers.UploadProfilePhotoByEmployee(db, secretKey))
+	e.DELETE("/profile/photo", controllers.DeleteProfilePhotoByEmployee(db, secretKey))
 	e.PUT("/profile/change-password", controllers.UpdateEmployeePassword(db, secretKey))
 
 	//Employee Attandance
@@ -310,6 +465,21 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	// Tambahkan pada main atau tempat lainnya
 	e.GET("/employee/attendance", controllers.EmployeeAttendance(db, secretKey))
 	e.GET("/employee/attendance/:id", controllers.EmployeeAttendanceByID(db, secretKey))
+	e.POST("/employee/attendance/kiosk_checkin", controllers.EmployeeKioskQRCheckIn(db, secretKey))
+	e.POST("/employee/attendance/kiosk_checkout", controllers.EmployeeKioskQRCheckOut(db, secretKey))
+
+	//Attendance Correction Employee
+	e.POST("/employee/attendance_corrections", controllers.CreateAttendanceCorrectionByEmployee(db, secretKey))
+	e.GET("/employee/attendance_corrections", controllers.GetAllAttendanceCorrectionsByEmployee(db, secretKey))
+	e.DELETE("/employee/attendance_corrections/:id", controllers.DeleteAttendanceCorrectionByEmployee(db, secretKey))
+	e.GET("/employee/attendance_corrections/team", controllers.GetTeamAttendanceCorrectionsByEmployee(db, secretKey))
+	e.PUT("/employee/attendance_corrections/:id/review", controllers.ReviewAttendanceCorrectionByManager(db, secretKey))
+
+	//Shift Roster Employee
+	e.GET("/employee/shift_rosters", controllers.GetShiftRosterByEmployee(db, secretKey))
+	e.POST("/employee/shift_swaps", controllers.CreateShiftSwapRequestByEmployee(db, secretKey))
+	e.GET("/employee/shift_swaps", controllers.GetAllShiftSwapRequestsByEmployee(db, secretKey))
+	e.DELETE("/employee/shift_swaps/:id", controllers.DeleteShiftSwapRequestByEmployee(db, secretKey))
 
 	//Project Employee
 	e.POST("/employee/projects", controllers.AddProjectByEmployee(db, secretKey))
@@ -340,10 +510,17 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	//Overtime Request Employee
 	e.POST("/employee/overtime_requests", controllers.CreateOvertimeRequestByEmployee(db, secretKey))
 	e.GET("/employee/overtime_requests", controllers.GetAllOvertimeRequestsByEmployee(db, secretKey))
+	e.GET("/employee/overtime_requests/suggestions", controllers.GetOvertimeSuggestionsByEmployee(db, secretKey))
 	e.GET("/employee/overtime_requests/:id", controllers.GetOvertimeRequestByIDByEmployee(db, secretKey))
 	e.PUT("/employee/overtime_requests/:id", controllers.UpdateOvertimeRequestByIDByEmployee(db, secretKey))
 	e.DELETE("/employee/overtime_requests/:id", controllers.DeleteOvertimeRequestByIDByEmployee(db, secretKey))
 
+	//Employee Document
+	e.POST("/employee/documents", controllers.UploadEmployeeDocumentByEmployee(db, secretKey))
+	e.GET("/employee/documents", controllers.GetAllEmployeeDocumentsByEmployee(db, secretKey))
+	e.GET("/employee/documents/:id/download", controllers.DownloadEmployeeDocumentByEmployee(db, secretKey))
+	e.DELETE("/employee/documents/:id", controllers.DeleteEmployeeDocumentByEmployee(db, secretKey))
+
 	//Training Employee
 	e.GET("/employee/trainings", controllers.GetTrainingByEmployeeID(db, secretKey))
 	e.GET("/employee/trainings/:id", controllers.GetTrainingByIDByEmployee(db, secretKey))
@@ -366,6 +543,34 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.PUT("/employee/request_loans/:id", controllers.UpdateRequestLoanByIDByEmployee(db, secretKey))
 	e.DELETE("/employee/request_loans/:id", controllers.DeleteRequestLoanByIDByEmployee(db, secretKey))
 
+	//Policy Employee
+	e.GET("/employee/policies", controllers.GetPoliciesByEmployee(db, secretKey))
+	e.GET("/employee/policies/:id", controllers.GetPolicyByIDByEmployee(db, secretKey))
+	e.POST("/employee/policies/:id/acknowledge", controllers.AcknowledgePolicyByEmployee(db, secretKey))
+	e.GET("/employee/policies/:id/document", controllers.DownloadPolicyDocumentByEmployee(db, secretKey))
+
+	//Announcement Employee
+	e.GET("/employee/announcements", controllers.GetAnnouncementsByEmployee(db, secretKey))
+	e.GET("/employee/announcements/:id", controllers.GetAnnouncementByIDByEmployee(db, secretKey))
+	e.POST("/employee/announcements/:id/acknowledge", controllers.AcknowledgeAnnouncementByEmployee(db, secretKey))
+
+	//Interview Employee
+	e.GET("/employee/interviews", controllers.GetInterviewsByEmployee(db, secretKey))
+	e.GET("/employee/interviews/:id", controllers.GetInterviewByIDByEmployee(db, secretKey))
+	e.GET("/employee/interviews/:id/cv", controllers.DownloadInterviewCandidateCVByEmployee(db, secretKey))
+	e.POST("/employee/interviews/:id/scorecard", controllers.SubmitInterviewScorecardByEmployee(db, secretKey))
+
+	//Onboarding Employee
+	e.GET("/employee/onboarding", controllers.GetOnboardingByEmployee(db, secretKey))
+	e.GET("/employee/onboarding_tasks", controllers.GetOnboardingTasksByEmployee(db, secretKey))
+	e.PUT("/employee/onboarding_tasks/:id/complete", controllers.CompleteOnboardingTaskByEmployee(db, secretKey))
+
+	//Offboarding Employee
+	e.GET("/employee/offboarding", controllers.GetOffboardingByEmployee(db, secretKey))
+	e.GET("/employee/offboarding/settlement/pdf", controllers.DownloadFinalSettlementPDFByEmployee(db, secretKey))
+	e.GET("/employee/offboarding_tasks", controllers.GetOffboardingTasksByEmployee(db, secretKey))
+	e.PUT("/employee/offboarding_tasks/:id/complete", controllers.CompleteOffboardingTaskByEmployee(db, secretKey))
+
 	//Helpdesk Employee
 	e.POST("/employee/helpdesks", controllers.CreateHelpdeskByEmployee(db, secretKey))
 	e.GET("/employee/helpdesks", controllers.GetAllHelpdeskByEmployee(db, secretKey))
@@ -373,6 +578,17 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.PUT("/employee/helpdesks/:id", controllers.UpdateHelpdeskByIDByEmployee(db, secretKey))
 	e.DELETE("/employee/helpdesks/:id", controllers.DeleteHelpdeskByIDByEmployee(db, secretKey))
 	e.GET("/employee/helpdesks/progress-bar", controllers.GetHelpdeskStatsByEmployee(db, secretKey))
+	e.POST("/employee/helpdesks/:id/comments", controllers.CreateHelpdeskCommentByEmployee(db, secretKey))
+	e.GET("/employee/helpdesks/:id/comments", controllers.GetHelpdeskCommentsByEmployee(db, secretKey))
+	e.GET("/employee/helpdesks/:id/status_history", controllers.GetHelpdeskStatusHistoryByEmployee(db, secretKey))
+	e.GET("/employee/helpdesks/:id/attachments/:attachment_id", controllers.DownloadHelpdeskAttachmentByEmployee(db, secretKey))
+
+	//Knowledge Base Employee
+	e.GET("/employee/knowledge_base/categories", controllers.GetAllKnowledgeBaseCategories(db, secretKey))
+	e.GET("/employee/knowledge_base/articles", controllers.GetAllKnowledgeBaseArticlesByEmployee(db, secretKey))
+	e.GET("/employee/knowledge_base/articles/:id", controllers.GetKnowledgeBaseArticleByIDByEmployee(db, secretKey))
+	e.POST("/employee/knowledge_base/articles/:id/feedback", controllers.SubmitKnowledgeBaseFeedbackByEmployee(db, secretKey))
+	e.POST("/employee/knowledge_base/suggestions/:id/resolved", controllers.ResolveKnowledgeBaseSuggestionByEmployee(db, secretKey))
 
 	//Leave Request Employee
 	e.GET("/employee/leave_request_types", controllers.GetAllLeaveRequestTypesByEmployee(db, secretKey))
@@ -402,6 +618,13 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.POST("/admin/employees/multiple", controllers.CreateMultipleEmployeeAccountsByAdmin(db, secretKey))
 
 	e.GET("/admin/employee_attendance_report", controllers.GetEmployeeAttendanceReport(db, secretKey))
+	e.GET("/admin/attendance_timesheet", controllers.ExportAttendanceTimesheetByAdmin(db, secretKey))
+
+	//Attendance Analytics
+	e.GET("/admin/attendance_analytics/late_trends", controllers.GetLateTrendsByAdmin(db, secretKey))
+	e.GET("/admin/attendance_analytics/bradford", controllers.GetBradfordFactorByAdmin(db, secretKey))
+	e.GET("/admin/attendance_analytics/top", controllers.GetTopAttendanceOffendersByAdmin(db, secretKey))
+	e.GET("/admin/attendance_analytics/flags", controllers.GetAttendanceFlagsByAdmin(db, secretKey))
 
 	// Chatbot untuk user dapat bertanya dengan Debot rekomendasi tempat wisata
 	harmonyUsecase := controllers.NewHarmonyUsecase()